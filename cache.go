@@ -0,0 +1,337 @@
+package negotiate
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is an in-process HTTP response cache that understands the negotiation middlewares in this package.
+//
+// Responses are stored under the request URL and the items chosen by the middlewares, as
+// retrieved using Item(r, header), rather than the raw values of the request headers.
+// Requests such as "Accept: */*" and "Accept: application/json" that resolve to the same item
+// are therefore served the same stored response.
+//
+// Only successful responses to GET requests with an explicit freshness lifetime are stored.
+// Since the cache is shared between users, responses to requests with an Authorization header
+// are only stored if they are explicitly marked as shareable, and responses that set cookies are never stored.
+//
+// When the cache is full, the least recently used responses are discarded.
+// The zero value is not usable; use NewCache.
+type Cache struct {
+	headers []string
+
+	// MaxBodySize is the largest response body, in bytes, that will be stored.
+	MaxBodySize int
+
+	// MaxEntries is the largest number of responses that will be stored.
+	// A negative value disables the limit.
+	MaxEntries int
+
+	// MaxBytes is the largest total size, in bytes, of the response bodies that will be stored.
+	// A negative value disables the limit.
+	MaxBytes int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // of *cacheEntry, most recently used first
+	size    int
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	header  http.Header
+	body    []byte
+	stored  time.Time
+	expires time.Time
+}
+
+// NewCache returns a Cache that keys responses on the items negotiated for the given headers.
+//
+// Its Middleware must be placed inside the negotiation middlewares for those headers,
+// so that their results are available when it runs.
+func NewCache(headers ...string) *Cache {
+	canonical := make([]string, len(headers))
+
+	for i, header := range headers {
+		canonical[i] = http.CanonicalHeaderKey(header)
+	}
+
+	return &Cache{
+		headers:     canonical,
+		MaxBodySize: 1 << 20,
+		MaxEntries:  1024,
+		MaxBytes:    32 << 20,
+		entries:     map[string]*list.Element{},
+		lru:         list.New(),
+		now:         time.Now,
+	}
+}
+
+// key returns the cache key for r.
+func (c *Cache) key(r *http.Request) string {
+	items := make([]string, len(c.headers))
+
+	for i, header := range c.headers {
+		items[i] = Item(r, header)
+	}
+
+	return r.URL.String() + "\n" + formatCacheKey(c.headers, items)
+}
+
+// cacheDirectives parses a Cache-Control header into a map of directive names to their values.
+func cacheDirectives(h http.Header) map[string]string {
+	directives := map[string]string{}
+
+	for _, directive := range strings.Split(FieldValue(h, "Cache-Control"), ",") {
+		name, value := strings.TrimSpace(directive), ""
+
+		if i := strings.IndexByte(name, '='); i >= 0 {
+			name, value = name[:i], strings.Trim(name[i+1:], `"`)
+		}
+
+		if name != "" {
+			directives[strings.ToLower(name)] = value
+		}
+	}
+
+	return directives
+}
+
+// freshness returns how long a response with the given header may be served from the cache,
+// or zero if it must not be stored.
+//
+// If authorized is true, the request had an Authorization header.
+func (c *Cache) freshness(status int, h http.Header, authorized bool) time.Duration {
+	directives := cacheDirectives(h)
+
+	if status != http.StatusOK || h.Get("Set-Cookie") != "" {
+		return 0
+	}
+
+	for _, name := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[name]; ok {
+			return 0
+		}
+	}
+
+	// RFC 9111, section 3.5: a shared cache may only reuse a response to an authorized request
+	// if the response explicitly allows it.
+	if authorized {
+		shareable := false
+
+		for _, name := range []string{"public", "s-maxage", "must-revalidate"} {
+			_, ok := directives[name]
+			shareable = shareable || ok
+		}
+
+		if !shareable {
+			return 0
+		}
+	}
+
+	// The response may only be reused if it varies on nothing but the negotiated headers.
+	for _, vary := range strings.Split(FieldValue(h, "Vary"), ",") {
+		if vary = http.CanonicalHeaderKey(strings.TrimSpace(vary)); vary == "" {
+			continue
+		}
+
+		known := false
+
+		for _, header := range c.headers {
+			known = known || header == vary
+		}
+
+		if !known {
+			return 0
+		}
+	}
+
+	age, ok := directives["s-maxage"]
+
+	if !ok {
+		age = directives["max-age"]
+	}
+
+	seconds, err := strconv.Atoi(age)
+
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// etagMatches reports whether the If-None-Match header of r matches etag, using the weak comparison function.
+func etagMatches(r *http.Request, etag string) bool {
+	if etag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(FieldValue(r.Header, "If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookup returns the fresh entry stored under key, if there is one.
+func (c *Cache) lookup(key string, now time.Time) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element := c.entries[key]
+
+	if element == nil {
+		return nil
+	}
+
+	if entry := element.Value.(*cacheEntry); now.Before(entry.expires) {
+		c.lru.MoveToFront(element)
+		return entry
+	}
+
+	c.remove(element)
+
+	return nil
+}
+
+// store stores entry, replacing any entry with the same key,
+// and then discards the least recently used entries until the cache is within its limits.
+func (c *Cache) store(entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element := c.entries[entry.key]; element != nil {
+		c.remove(element)
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.size += len(entry.body)
+
+	for c.lru.Len() != 0 && (exceeds(c.lru.Len(), c.MaxEntries) || exceeds(c.size, c.MaxBytes)) {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove discards a stored entry. The caller must hold c.mu.
+func (c *Cache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.body)
+}
+
+// serve writes a response from entry.
+func (c *Cache) serve(w http.ResponseWriter, r *http.Request, entry *cacheEntry, now time.Time) {
+	for name, values := range entry.header {
+		w.Header()[name] = append([]string(nil), values...)
+	}
+
+	w.Header().Set("Age", strconv.Itoa(int(now.Sub(entry.stored)/time.Second)))
+
+	if etagMatches(r, entry.header.Get("ETag")) {
+		w.Header().Del("Content-Length")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(entry.body)
+}
+
+// cacheRecorder passes a response through to the client, while keeping a copy of it.
+type cacheRecorder struct {
+	http.ResponseWriter
+	maxBodySize int
+	status      int
+	header      http.Header
+	body        bytes.Buffer
+	overflow    bool
+}
+
+func (rec *cacheRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+		rec.header = rec.ResponseWriter.Header().Clone()
+	}
+
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *cacheRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.WriteHeader(http.StatusOK)
+	}
+
+	if !rec.overflow {
+		if rec.body.Len()+len(b) > rec.maxBodySize {
+			rec.overflow = true
+			rec.body = bytes.Buffer{}
+		} else {
+			rec.body.Write(b)
+		}
+	}
+
+	return rec.ResponseWriter.Write(b)
+}
+
+// Middleware returns a handler that serves stored responses when it can,
+// and otherwise invokes next, storing its response if permitted.
+//
+// Requests with a Cache-Control of no-cache or no-store are never served from the cache,
+// and responses are only stored if they have a Cache-Control of max-age or s-maxage without
+// no-store, no-cache or private, don't set a cookie, and don't vary on anything but the negotiated headers.
+// A response to a request with an Authorization header is only stored if its Cache-Control
+// also includes public, s-maxage or must-revalidate.
+//
+// If-None-Match is honoured when serving stored responses that have an ETag.
+func (c *Cache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var (
+			key        = c.key(r)
+			now        = c.now()
+			directives = cacheDirectives(r.Header)
+		)
+
+		_, noStore := directives["no-store"]
+		_, noCache := directives["no-cache"]
+
+		if !noStore && !noCache {
+			if entry := c.lookup(key, now); entry != nil {
+				c.serve(w, r, entry, now)
+				return
+			}
+		}
+
+		rec := &cacheRecorder{ResponseWriter: w, maxBodySize: c.MaxBodySize}
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			// Nothing was written, which is an empty 200 response.
+			rec.status, rec.header = http.StatusOK, w.Header().Clone()
+		}
+
+		if noStore || rec.overflow {
+			return
+		}
+
+		if lifetime := c.freshness(rec.status, rec.header, r.Header.Get("Authorization") != ""); lifetime > 0 {
+			c.store(&cacheEntry{key, rec.header, rec.body.Bytes(), now, now.Add(lifetime)})
+		}
+	})
+}
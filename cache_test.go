@@ -0,0 +1,147 @@
+package negotiate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func ExampleCache() {
+	cache := NewCache("Accept")
+	calls := 0
+
+	handler := ContentTypeMiddleware("application/json", "text/html")(
+		cache.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Cache-Control", "max-age=60")
+			fmt.Fprintf(w, "%s response #%d", ContentType(r), calls)
+		})))
+
+	for _, accept := range []string{"*/*", "application/json", "application/json, text/*;q=0.5", "text/html"} {
+		r := httptest.NewRequest("GET", "/data", nil)
+		r.Header.Set("Accept", accept)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		fmt.Printf("%-32q -> %s\n", accept, w.Body)
+	}
+
+	// Output:
+	// "*/*"                            -> application/json response #1
+	// "application/json"               -> application/json response #1
+	// "application/json, text/*;q=0.5" -> application/json response #1
+	// "text/html"                      -> text/html response #2
+}
+
+func TestCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	calls := 0
+
+	cache := NewCache("Accept-Language")
+	cache.now = func() time.Time { return now }
+
+	handler := LanguageMiddleware("en", "fr")(cache.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", r.URL.Query().Get("cc"))
+		w.Header().Add("Vary", r.URL.Query().Get("vary"))
+		if r.URL.Query().Get("cookie") != "" {
+			w.Header().Set("Set-Cookie", "session=1")
+		}
+		fmt.Fprint(w, Language(r))
+	})))
+
+	tests := []struct {
+		name       string
+		target     string
+		header     http.Header
+		advance    time.Duration
+		wantStatus int
+		wantCalls  int
+		wantCached bool
+	}{
+		{"miss", "/?cc=max-age=10", nil, 0, 200, 1, false},
+		{"hit", "/?cc=max-age=10", nil, 5 * time.Second, 200, 1, true},
+		{"not modified", "/?cc=max-age=10", http.Header{"If-None-Match": {`W/"v1"`}}, 0, 304, 1, true},
+		{"request no-cache", "/?cc=max-age=10", http.Header{"Cache-Control": {"no-cache"}}, 0, 200, 2, false},
+		{"expired", "/?cc=max-age=10", nil, 10 * time.Second, 200, 3, false},
+		{"other language", "/?cc=max-age=10", http.Header{"Accept-Language": {"fr"}}, 0, 200, 4, false},
+		{"no-store", "/?cc=no-store,max-age=10", nil, 0, 200, 5, false},
+		{"no-store again", "/?cc=no-store,max-age=10", nil, 0, 200, 6, false},
+		{"private", "/?cc=private,max-age=10", nil, 0, 200, 7, false},
+		{"private again", "/?cc=private,max-age=10", nil, 0, 200, 8, false},
+		{"vary cookie", "/?cc=max-age=10&vary=Cookie", nil, 0, 200, 9, false},
+		{"vary cookie again", "/?cc=max-age=10&vary=Cookie", nil, 0, 200, 10, false},
+		{"s-maxage", "/?cc=max-age=0,s-maxage=10", nil, 0, 200, 11, false},
+		{"s-maxage hit", "/?cc=max-age=0,s-maxage=10", nil, 0, 200, 11, true},
+		{"authorized", "/me?cc=max-age=10", http.Header{"Authorization": {"alice"}}, 0, 200, 12, false},
+		{"authorized again", "/me?cc=max-age=10", http.Header{"Authorization": {"bob"}}, 0, 200, 13, false},
+		{"authorized public", "/me?cc=public,max-age=10", http.Header{"Authorization": {"alice"}}, 0, 200, 14, false},
+		{"authorized public hit", "/me?cc=public,max-age=10", http.Header{"Authorization": {"bob"}}, 0, 200, 14, true},
+		{"authorized must-revalidate", "/me?cc=must-revalidate,max-age=10", http.Header{"Authorization": {"alice"}}, 0, 200, 15, false},
+		{"authorized must-revalidate hit", "/me?cc=must-revalidate,max-age=10", nil, 0, 200, 15, true},
+		{"set-cookie", "/?cc=max-age=10&cookie=1", nil, 0, 200, 16, false},
+		{"set-cookie again", "/?cc=max-age=10&cookie=1", nil, 0, 200, 17, false},
+	}
+	for _, tt := range tests {
+		now = now.Add(tt.advance)
+
+		r := httptest.NewRequest("GET", tt.target, nil)
+		for name, values := range tt.header {
+			r.Header[name] = values
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tt.wantStatus || calls != tt.wantCalls || (w.Header().Get("Age") != "") != tt.wantCached {
+			t.Errorf("%s: status = %d, calls = %d, Age = %q; want status %d, calls %d, cached %t",
+				tt.name, w.Code, calls, w.Header().Get("Age"), tt.wantStatus, tt.wantCalls, tt.wantCached)
+		}
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	cache := NewCache()
+	cache.MaxEntries = 2
+	cache.MaxBytes = 10
+
+	handler := cache.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, r.URL.Query().Get("body"))
+	}))
+
+	cached := func(target string) bool {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		return w.Header().Get("Age") != ""
+	}
+
+	cached("/a")
+	cached("/b")
+
+	// Using /a makes /b the least recently used, so it is discarded to make room for /c.
+	if !cached("/a") {
+		t.Error("/a was not cached")
+	}
+
+	cached("/c")
+
+	stored := func(target string) bool {
+		return cache.entries[cache.key(httptest.NewRequest("GET", target, nil))] != nil
+	}
+
+	if stored("/b") || !stored("/a") || !stored("/c") {
+		t.Errorf("stored /a, /b, /c = %t, %t, %t; want true, false, true", stored("/a"), stored("/b"), stored("/c"))
+	}
+
+	// A body larger than MaxBytes evicts everything, including itself.
+	cached("/d?body=0123456789a")
+
+	if len(cache.entries) != 0 || cache.size != 0 {
+		t.Errorf("cache holds %d entries of %d bytes, want none", len(cache.entries), cache.size)
+	}
+}
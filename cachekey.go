@@ -0,0 +1,59 @@
+package negotiate
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// formatCacheKey formats the items chosen for each header as a structured field dictionary,
+// ordered by header name so that the result doesn't depend on the order headers are given in.
+func formatCacheKey(headers, items []string) string {
+	order := make([]int, len(headers))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return headers[order[i]] < headers[order[j]]
+	})
+
+	members := make([]string, len(headers))
+
+	for i, j := range order {
+		members[i] = strings.ToLower(headers[j]) + "=" + sfItem(items[j])
+	}
+
+	return strings.Join(members, ", ")
+}
+
+// CacheKey negotiates on each axis for r, and returns a compact key made up of the chosen items,
+// such as "accept=text/html, accept-language=en".
+//
+// Requests with different headers that resolve to the same items have the same key,
+// so a cache can use it in place of the raw values of the headers listed in Vary.
+// The key doesn't include the request URL; callers should combine the two themselves.
+//
+// The key is deterministic: it doesn't depend on the order of the axes.
+//
+// If negotiation fails for any axis, its error is returned, in which case
+// the response shouldn't be served from or stored in a cache.
+func CacheKey(r *http.Request, axes ...Axis) (string, error) {
+	headers := make([]string, len(axes))
+	items := make([]string, len(axes))
+
+	for i, axis := range axes {
+		headers[i] = http.CanonicalHeaderKey(axis.Header)
+
+		item, err := axis.Negotiate.ProcessRequest(r, headers[i])
+
+		if err != nil {
+			return "", err
+		}
+
+		items[i] = item
+	}
+
+	return formatCacheKey(headers, items), nil
+}
@@ -0,0 +1,33 @@
+package negotiate
+
+import (
+	"fmt"
+	"net/http/httptest"
+)
+
+func ExampleCacheKey() {
+	axes := []Axis{
+		{"Accept-Language", Make(ParseLocale, "en", "fr")},
+		{"Accept", Make(ParseMedia, "text/html", "application/json")},
+	}
+
+	for _, accept := range []string{
+		"*/*",
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		"application/json",
+		"application/json, text/plain, */*",
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", accept)
+		r.Header.Set("Accept-Language", "en-GB,en;q=0.9")
+
+		key, _ := CacheKey(r, axes...)
+		fmt.Println(key)
+	}
+
+	// Output:
+	// accept=text/html, accept-language=en
+	// accept=text/html, accept-language=en
+	// accept=application/json, accept-language=en
+	// accept=application/json, accept-language=en
+}
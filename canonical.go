@@ -0,0 +1,165 @@
+package negotiate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// grandfatheredTags maps the grandfathered and redundant tags of the IANA language subtag registry
+// that have a preferred value, from lower case to that value.
+var grandfatheredTags = map[string]string{
+	"art-lojban": "jbo",
+	"en-gb-oed":  "en-GB-oxendict",
+	"i-ami":      "ami",
+	"i-bnn":      "bnn",
+	"i-hak":      "hak",
+	"i-klingon":  "tlh",
+	"i-lux":      "lb",
+	"i-navajo":   "nv",
+	"i-pwn":      "pwn",
+	"i-tao":      "tao",
+	"i-tay":      "tay",
+	"i-tsu":      "tsu",
+	"no-bok":     "nb",
+	"no-nyn":     "nn",
+	"sgn-be-fr":  "sfb",
+	"sgn-be-nl":  "vgt",
+	"sgn-ch-de":  "sgg",
+	"zh-guoyu":   "zh",
+	"zh-hakka":   "hak",
+	"zh-min-nan": "nan",
+	"zh-xiang":   "hsn",
+	"sgn-br":     "bzs",
+	"sgn-de":     "gsg",
+	"sgn-fr":     "fsl",
+	"sgn-gb":     "bfi",
+	"sgn-jp":     "jsl",
+	"sgn-us":     "ase",
+}
+
+// languageAliases maps deprecated language subtags to their preferred values, as given by the IANA registry,
+// along with the legacy and macrolanguage aliases of CLDR.
+var languageAliases = map[string]string{
+	// Deprecated by the IANA registry.
+	"in": "id",
+	"iw": "he",
+	"ji": "yi",
+	"jw": "jv",
+	"mo": "ro",
+
+	// Legacy aliases of CLDR, which the IANA registry doesn't deprecate.
+	"sh": "sr-Latn",
+	"tl": "fil",
+
+	// Norwegian is almost always written as Bokmål.
+	"no":  "nb",
+	"nob": "nb",
+	"nno": "nn",
+
+	// Macrolanguages.
+	"arb": "ar",
+	"azj": "az",
+	"cmn": "zh",
+	"ekk": "et",
+	"khk": "mn",
+	"knn": "kok",
+	"lvs": "lv",
+	"npi": "ne",
+	"ory": "or",
+	"pes": "fa",
+	"swh": "sw",
+	"uzn": "uz",
+	"zsm": "ms",
+}
+
+// regionAliases maps deprecated region subtags to their preferred values.
+var regionAliases = map[string]string{
+	"BU": "MM",
+	"DD": "DE",
+	"FX": "FR",
+	"TP": "TL",
+	"UK": "GB",
+	"YD": "YE",
+	"ZR": "CD",
+}
+
+// canonicalize returns l with its deprecated subtags replaced, its extended language subtag
+// replaced with the primary language it identifies, and its likely script added.
+func (l localeValue) canonicalize() localeValue {
+	if len(l.extlang) != 0 {
+		// RFC 5646, section 4.5: the extlang form is replaced by the primary language subtag.
+		l.language, l.extlang = l.extlang[0], nil
+	}
+
+	if alias, ok := languageAliases[l.language]; ok {
+		// An alias such as "sh" also implies a script.
+		a, _ := parseTag(strings.ToLower(alias))
+
+		if l.language = a.language; l.script == "" {
+			l.script = a.script
+		}
+	}
+
+	if alias, ok := regionAliases[l.region]; ok {
+		l.region = alias
+	}
+
+	if l.script == "" {
+		if script, ok := likelyScripts[l.language+"-"+l.region]; ok {
+			l.script = script
+		} else if script, ok := likelyScripts[l.language]; ok {
+			l.script = script
+		}
+	}
+
+	return l
+}
+
+// CanonicalizeLocale returns the canonical form of a BCP 47 language tag.
+//
+// Grandfathered and redundant tags, along with deprecated language and region subtags,
+// are replaced by their preferred values from the IANA language subtag registry.
+// Extended language subtags are replaced by the primary language subtag, and individual languages
+// by the macrolanguage that is conventionally used in their place, so "zh-cmn-TW" becomes "zh-Hant-TW".
+// Norwegian ("no") is mapped to Bokmål ("nb").
+//
+// The likely script of the language is then added, following the likely subtags data of CLDR,
+// which is embedded in the package (currently CLDR 32), so that "zh-TW" becomes "zh-Hant-TW",
+// "sr" becomes "sr-Cyrl" and "ug" becomes "ug-Arab". The likely region isn't added,
+// as it would make the tag more specific than it was.
+//
+// Returns an error if the tag isn't well-formed.
+func CanonicalizeLocale(tag string) (string, error) {
+	lower := strings.ToLower(strings.Replace(tag, "_", "-", -1))
+
+	if preferred, ok := grandfatheredTags[lower]; ok {
+		lower = strings.ToLower(preferred)
+	}
+
+	l, ok := parseTag(lower)
+
+	if !ok {
+		return "", fmt.Errorf("bad locale: %q", tag)
+	}
+
+	return l.canonicalize().String(), nil
+}
+
+// CanonicalLocale returns a ValueParser that canonicalizes language tags using CanonicalizeLocale
+// before passing them to parser, so that offers and queries are compared in their canonical forms.
+//
+// Since canonicalization adds a script, a canonical query is often more specific than the offers,
+// so the parser is best taken from Lookup or Closest. For instance,
+// MakeLanguage(Lookup) with an offer of "zh-Hant" won't satisfy a query for "zh-TW", but
+// Make(CanonicalLocale(Lookup.Parser()), "zh-Hant").WithStrategy(LookupPreference) will.
+//
+// Values that can't be canonicalized, such as "*", are passed to parser unchanged.
+func CanonicalLocale(parser ValueParser) ValueParser {
+	return func(locale string) (Value, error) {
+		if canonical, err := CanonicalizeLocale(locale); err == nil {
+			return parser(canonical)
+		}
+
+		return parser(locale)
+	}
+}
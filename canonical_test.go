@@ -0,0 +1,93 @@
+package negotiate
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleCanonicalLocale() {
+	negotiate := Make(CanonicalLocale(Lookup.Parser()), "zh-Hans", "zh-Hant", "nb", "he", "id").
+		WithStrategy(LookupPreference)
+
+	for _, query := range []string{"zh-TW", "zh-CN", "no", "iw", "in", "zh-cmn-HK"} {
+		item, err := negotiate.Process(query)
+		fmt.Println(query, "->", item, err)
+	}
+
+	// Output:
+	// zh-TW -> zh-Hant <nil>
+	// zh-CN -> zh-Hans <nil>
+	// no -> nb <nil>
+	// iw -> he <nil>
+	// in -> id <nil>
+	// zh-cmn-HK -> zh-Hant <nil>
+}
+
+func TestCanonicalizeLocale(t *testing.T) {
+	tests := []struct {
+		tag, want string
+	}{
+		{"en", "en-Latn"},
+		{"en-us", "en-Latn-US"},
+		{"zh-TW", "zh-Hant-TW"},
+		{"zh_HK", "zh-Hant-HK"},
+		{"zh", "zh-Hans"},
+		{"zh-Hant-CN", "zh-Hant-CN"},
+		{"cmn-TW", "zh-Hant-TW"},
+		{"zh-yue", "yue-Hant"},
+		{"iw-IL", "he-Hebr-IL"},
+		{"in", "id-Latn"},
+		{"ji", "yi-Hebr"},
+		{"jw", "jv-Latn"},
+		{"mo", "ro-Latn"},
+		{"no", "nb-Latn"},
+		{"no-NO", "nb-Latn-NO"},
+		{"sh", "sr-Latn"},
+		{"sr", "sr-Cyrl"},
+		{"sr-ME", "sr-Latn-ME"},
+		{"ug", "ug-Arab"},
+		{"ug-KZ", "ug-Cyrl-KZ"},
+		{"tl", "fil-Latn"},
+		{"de-DD", "de-Latn-DE"},
+		{"i-klingon", "tlh"},
+		{"art-lojban", "jbo-Latn"},
+		{"en-GB-oed", "en-Latn-GB-oxendict"},
+		{"i-default", "i-default"},
+		{"x-whatever", "x-whatever"},
+		{"tlh-u-co-phonebk", "tlh-u-co-phonebk"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := CanonicalizeLocale(tt.tag)
+
+			if err != nil || got != tt.want {
+				t.Errorf("CanonicalizeLocale(%q) = %q, %v, want %q", tt.tag, got, err, tt.want)
+			}
+
+			// Canonicalization is idempotent.
+			if again, err := CanonicalizeLocale(got); err != nil || again != got {
+				t.Errorf("CanonicalizeLocale(%q) = %q, %v", got, again, err)
+			}
+		})
+	}
+
+	for _, tag := range []string{"*", "", "en--US", "x"} {
+		if got, err := CanonicalizeLocale(tag); err == nil {
+			t.Errorf("CanonicalizeLocale(%q) = %q, want error", tag, got)
+		}
+	}
+}
+
+func TestCanonicalLocale(t *testing.T) {
+	n := Make(CanonicalLocale(Lookup.Parser()), "zh-Hant", "en").WithStrategy(LookupPreference)
+
+	for query, want := range map[string]string{"zh-TW": "zh-Hant", "zh-Hant-HK": "zh-Hant", "en-US": "en", "*": "zh-Hant"} {
+		if got, err := n.Process(query); err != nil || got != want {
+			t.Errorf("Process(%q) = %q, %v, want %q", query, got, err, want)
+		}
+	}
+
+	if _, err := Make(CanonicalLocale(ExtendedFiltering.Parser()), "de-DE").Process("de-*-DE"); err != nil {
+		t.Errorf("Process(%q) returned error: %v", "de-*-DE", err)
+	}
+}
@@ -0,0 +1,95 @@
+package negotiate
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+)
+
+// Choice is one of the variants listed in a 300: Multiple Choices response.
+type Choice struct {
+	// Href is the URL of the variant.
+	Href string `json:"href"`
+
+	// Item is the item the variant was offered as.
+	Item string `json:"item"`
+
+	// Type and Language are the media type and language of the variant,
+	// if the header negotiated on was Accept or Accept-Language respectively.
+	Type     string `json:"type,omitempty"`
+	Language string `json:"hreflang,omitempty"`
+}
+
+// link returns c formatted as an RFC 8288 link-value.
+func (c Choice) link() string {
+	link := "<" + c.Href + `>; rel="alternate"`
+
+	if c.Type != "" {
+		link += "; type=" + quote(c.Type)
+	}
+
+	if c.Language != "" {
+		link += "; hreflang=" + quote(c.Language)
+	}
+
+	return link
+}
+
+// choicesFormats are the formats a list of choices can be written in, most preferred first.
+var choicesFormats = Make(ParseMedia, "text/html", "application/json")
+
+var choicesTemplate = template.Must(template.New("choices").Parse(`<!DOCTYPE html>
+<html>
+<head><title>300: Multiple Choices</title></head>
+<body>
+<h1>300: Multiple Choices</h1>
+<ul>
+{{- range .}}
+<li><a href="{{.Href}}"{{with .Type}} type="{{.}}"{{end}}{{with .Language}} hreflang="{{.}}"{{end}}>{{.Item}}</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+// MultipleChoices returns a Hook for OnNotAcceptable that responds with a 300: Multiple Choices
+// listing every offered item, so that the client can pick one for itself.
+//
+// url is called with each item, and must return the URL the variant for that item can be found at.
+//
+// A Link header is added for each variant, along with a body in either text/html or application/json,
+// whichever best satisfies the Accept header of the request.
+func MultipleChoices(url func(item string) string) Hook {
+	return func(w http.ResponseWriter, r *http.Request, e Event) {
+		choices := make([]Choice, len(e.Items))
+
+		for i, item := range e.Items {
+			choices[i] = Choice{Href: url(item), Item: item}
+
+			switch e.Header {
+			case "Accept":
+				choices[i].Type = item
+			case "Accept-Language":
+				choices[i].Language = item
+			}
+
+			w.Header().Add("Link", choices[i].link())
+		}
+
+		format := writeHeaderFor(w, r, choicesFormats, http.StatusMultipleChoices)
+
+		if format == "application/json" {
+			json.NewEncoder(w).Encode(struct {
+				Header  string   `json:"header"`
+				Choices []Choice `json:"choices"`
+			}{e.Header, choices})
+		} else {
+			choicesTemplate.Execute(w, choices)
+		}
+	}
+}
+
+// WithMultipleChoices is an Option that sets MultipleChoices(url) as the OnNotAcceptable hook.
+func WithMultipleChoices(url func(item string) string) Option {
+	return OnNotAcceptable(MultipleChoices(url))
+}
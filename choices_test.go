@@ -0,0 +1,37 @@
+package negotiate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+)
+
+func ExampleWithMultipleChoices() {
+	middleware := MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr"),
+		WithMultipleChoices(func(item string) string {
+			return "/report." + item + ".html"
+		}))
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest("GET", "/report", nil)
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("Accept-Language", "de")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	fmt.Println(w.Code)
+	for _, link := range w.Header().Values("Link") {
+		fmt.Println("Link:", link)
+	}
+	io.Copy(os.Stdout, w.Result().Body)
+
+	// Output:
+	// 300
+	// Link: </report.en.html>; rel="alternate"; hreflang=en
+	// Link: </report.fr.html>; rel="alternate"; hreflang=fr
+	// {"header":"Accept-Language","choices":[{"href":"/report.en.html","item":"en","hreflang":"en"},{"href":"/report.fr.html","item":"fr","hreflang":"fr"}]}
+}
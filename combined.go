@@ -0,0 +1,40 @@
+package negotiate
+
+import (
+	"net/http"
+)
+
+// Axis pairs a header with the Negotiate used to negotiate on it.
+type Axis struct {
+	Header    string
+	Negotiate Negotiate
+}
+
+// CombinedMiddleware returns http middleware that negotiates on several headers in a single pass,
+// behaving like a stack of Middleware for each axis, but reporting every failure at once.
+//
+// All of the headers are added to the Vary header of the response in one go.
+//
+// If every header has a matching item, the next handler will be invoked.
+// The matching items can be retrieved using Item(r, header) or Negotiated(r, header), as for Middleware.
+//
+// Otherwise a single error is generated, listing every header that failed, in order,
+// along with the supported values of each header that had no match.
+// Its status is 431: Request Header Fields Too Large if any header exceeds the limits of its Negotiate,
+// or else 400: Bad Request if any header fails to parse, or else 406: Not Acceptable.
+func CombinedMiddleware(axes ...Axis) func(http.Handler) http.Handler {
+	return CombinedMiddlewareFor(axes)
+}
+
+// CombinedMiddlewareFor is like CombinedMiddleware, but accepts options to change the behaviour of the middleware,
+// as for MiddlewareFor.
+//
+// The Policy applies to each axis in turn, so an item given to Fallback must be accepted by the parser of every axis.
+// OnNegotiated is called once for each axis, in order.
+// OnNotAcceptable and OnBadRequest are called once, with an Event describing the failure that decided the status,
+// and every failure in its Failures.
+//
+// This function will panic if an item given by the options fails to parse.
+func CombinedMiddlewareFor(axes []Axis, options ...Option) func(http.Handler) http.Handler {
+	return newHandler(axes, options)
+}
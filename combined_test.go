@@ -0,0 +1,102 @@
+package negotiate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func ExampleCombinedMiddleware() {
+	middleware := CombinedMiddleware(
+		Axis{"Accept", Make(ParseMedia, "text/html", "application/json")},
+		Axis{"Accept-Language", Make(ParseLocale, "en", "fr")},
+	)
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "Negotiated %s in %s\n", ContentType(r), Language(r))
+	}))
+
+	requests := []struct {
+		accept, language string
+	}{
+		{"application/json", "fr-CA, en;q=0.5"},
+		{"image/png", "de"},
+		{"image/png", "i like waffles."},
+	}
+
+	for _, req := range requests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", req.accept)
+		r.Header.Set("Accept-Language", req.language)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		fmt.Printf("Accept=%q Accept-Language=%q Vary=%q\n", req.accept, req.language, w.Header().Values("Vary"))
+		io.Copy(os.Stdout, w.Result().Body)
+		fmt.Println()
+	}
+
+	// Output:
+	// Accept="application/json" Accept-Language="fr-CA, en;q=0.5" Vary=["Accept, Accept-Language"]
+	// Negotiated application/json in en
+	//
+	// Accept="image/png" Accept-Language="de" Vary=["Accept, Accept-Language"]
+	// 406: Not Acceptable
+	// Supported values for Accept header are: text/html, application/json
+	// Supported values for Accept-Language header are: en, fr
+	//
+	// Accept="image/png" Accept-Language="i like waffles." Vary=["Accept, Accept-Language"]
+	// 400: Bad Request
+	// Supported values for Accept header are: text/html, application/json
+	// Unable to parse Accept-Language header.
+}
+
+func TestCombinedMiddlewareFor(t *testing.T) {
+	axes := []Axis{
+		{"Accept-Encoding", Make(ParseSimple, "gzip", "identity")},
+		{"Accept-Language", Make(ParseLocale, "en", "fr")},
+	}
+
+	var negotiated []string
+
+	handler := CombinedMiddlewareFor(axes,
+		WithPolicy(FallbackFirst),
+		WithProblemDetails(),
+		OnNegotiated(func(w http.ResponseWriter, r *http.Request, e Event) {
+			negotiated = append(negotiated, e.Header+"="+e.Result.Item)
+		}),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "br")
+	r.Header.Set("Accept-Language", "fr")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if want := []string{"Accept-Encoding=gzip", "Accept-Language=fr"}; w.Code != http.StatusOK || !reflect.DeepEqual(negotiated, want) {
+		t.Errorf("got status %d and %q, want 200 and %q", w.Code, negotiated, want)
+	}
+
+	r.Header.Set("Accept", "application/problem+json")
+	r.Header.Set("Accept-Encoding", "gzip;q=x")
+	r.Header.Set("Accept-Language", "i like waffles.")
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	var p Problem
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Unable to parse Accept-Encoding header.\nUnable to parse Accept-Language header."; w.Code != http.StatusBadRequest || p.Header != "Accept-Encoding" || p.Detail != want {
+		t.Errorf("got status %d and %+v, want 400 for Accept-Encoding with detail %q", w.Code, p, want)
+	}
+}
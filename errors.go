@@ -0,0 +1,90 @@
+package negotiate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParseError is returned when a query fails to parse,
+// either because it is syntactically malformed or because the ValueParser rejected one of its items.
+//
+// Use errors.As to retrieve it from the errors returned by this package.
+type ParseError struct {
+	// Header is the name of the header the query came from,
+	// or empty if the query wasn't read from a header.
+	Header string
+
+	// Item is the text of the offending list element as it appears in the query,
+	// including any parameters and weight, but without surrounding whitespace.
+	// This is the same whether the element is malformed or was rejected by the ValueParser.
+	Item string
+
+	// Index is the position of the offending element in the list, counting from 0.
+	// Empty list elements are not counted.
+	Index int
+
+	// Offset is the byte offset of the error within the query.
+	// For requests with several field lines, this is an offset into their combined value.
+	Offset int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("item %d at offset %d: %v", e.Index, e.Offset, e.Err)
+
+	if e.Header != "" {
+		return "bad " + e.Header + " header: " + msg
+	}
+
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrNotAcceptable is returned when no item satisfies a query.
+var ErrNotAcceptable = errors.New("no item satisfies query")
+
+// NotAcceptableError is returned when no item satisfies a query.
+//
+// It matches ErrNotAcceptable when compared using errors.Is.
+type NotAcceptableError struct {
+	// Header is the name of the header the query came from,
+	// or empty if the query wasn't read from a header.
+	Header string
+
+	// Query is the parsed query.
+	Query Query
+
+	// Items holds the items that were offered.
+	Items []string
+}
+
+func (e *NotAcceptableError) Error() string {
+	return ErrNotAcceptable.Error()
+}
+
+func (e *NotAcceptableError) Unwrap() error {
+	return ErrNotAcceptable
+}
+
+// withHeader records the header a query came from in any error returned by this package.
+func withHeader(err error, header string) error {
+	var (
+		parseError         *ParseError
+		notAcceptableError *NotAcceptableError
+	)
+
+	if errors.As(err, &parseError) {
+		parseError.Header = header
+	}
+
+	if errors.As(err, &notAcceptableError) {
+		notAcceptableError.Header = header
+	}
+
+	return err
+}
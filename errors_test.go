@@ -0,0 +1,57 @@
+package negotiate
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		query string
+		want  ParseError
+	}{
+		{"gzip, br;q=0.5, what?", ParseError{Item: "what?", Index: 2, Offset: 16}},
+		{"gzip,, br;q=2", ParseError{Item: "br;q=2", Index: 1, Offset: 12}},
+		{`gzip;a="b, br`, ParseError{Item: `gzip;a="b, br`, Index: 0, Offset: 7}},
+
+		// Rejected by the ValueParser.
+		{"en, 1en;q=0.5", ParseError{Item: "1en;q=0.5", Index: 1, Offset: 4}},
+		{"en,  1en ;a=b", ParseError{Item: "1en ;a=b", Index: 1, Offset: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Make(ParseLocale, "en").Process(tt.query)
+
+			var got *ParseError
+			if !errors.As(err, &got) {
+				t.Fatalf("Process(%q) error = %v, want *ParseError", tt.query, err)
+			}
+
+			if got.Item != tt.want.Item || got.Index != tt.want.Index || got.Offset != tt.want.Offset || got.Err == nil {
+				t.Errorf("Process(%q) error = %#v, want %#v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotAcceptableError(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "br")
+
+	_, err := Make(ParseSimple, "identity", "gzip").ProcessRequest(r, "Accept-Encoding")
+
+	if !errors.Is(err, ErrNotAcceptable) {
+		t.Fatalf("ProcessRequest() error = %v, want ErrNotAcceptable", err)
+	}
+
+	var got *NotAcceptableError
+	if !errors.As(err, &got) {
+		t.Fatalf("ProcessRequest() error = %v, want *NotAcceptableError", err)
+	}
+
+	if got.Header != "Accept-Encoding" || got.Query.String() != "br" || !reflect.DeepEqual(got.Items, []string{"identity", "gzip"}) {
+		t.Errorf("ProcessRequest() error = %#v", got)
+	}
+}
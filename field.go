@@ -0,0 +1,373 @@
+package negotiate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// element is a single member of a comma separated list of weighted values,
+// such as one media range from an Accept header.
+type element struct {
+	// value is the text handed to the ValueParser, including any parameters
+	// that appeared before the weight, with surrounding whitespace removed.
+	value string
+
+	// q is the weight of the element, 1 if no weight was given.
+	q float64
+
+	// offset is the byte offset of the element within the field value.
+	offset int
+
+	// ext holds the parameters that followed the weight.
+	ext Params
+}
+
+// fieldScanner tokenizes a field value according to the list, parameter,
+// token and quoted-string rules of RFC 9110, section 5.6.
+type fieldScanner struct {
+	s      string
+	pos    int
+	limits Limits
+}
+
+func isTchar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+
+	return strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
+
+func isOWS(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func (s *fieldScanner) done() bool {
+	return s.pos >= len(s.s)
+}
+
+func (s *fieldScanner) peek() byte {
+	return s.s[s.pos]
+}
+
+func (s *fieldScanner) skipOWS() {
+	for !s.done() && isOWS(s.peek()) {
+		s.pos++
+	}
+}
+
+func (s *fieldScanner) errorf(format string, args ...interface{}) error {
+	return &ParseError{Offset: s.pos, Err: fmt.Errorf(format, args...)}
+}
+
+// checkLength returns an error if a value starting at offset exceeds MaxValueLength.
+func (s *fieldScanner) checkLength(offset int) error {
+	if exceeds(s.pos-offset, s.limits.MaxValueLength) {
+		return &ParseError{Offset: offset, Err: &LimitError{"MaxValueLength", s.limits.MaxValueLength}}
+	}
+
+	return nil
+}
+
+// token consumes and returns a (possibly empty) run of tchars.
+func (s *fieldScanner) token() string {
+	start := s.pos
+
+	for !s.done() && isTchar(s.peek()) {
+		s.pos++
+	}
+
+	return s.s[start:s.pos]
+}
+
+// quotedString consumes a quoted-string, returning its unescaped contents.
+func (s *fieldScanner) quotedString() (string, error) {
+	start := s.pos
+	s.pos++ // opening DQUOTE
+
+	var b strings.Builder
+
+	for !s.done() {
+		switch c := s.peek(); {
+		case c == '"':
+			s.pos++
+			return b.String(), nil
+		case c == '\\':
+			s.pos++
+			if s.done() {
+				break
+			}
+
+			if c := s.peek(); c != '\t' && (c < ' ' || c == 0x7f) {
+				return "", s.errorf("invalid quoted-pair")
+			}
+
+			b.WriteByte(s.peek())
+			s.pos++
+		case c != '\t' && (c < ' ' || c == 0x7f):
+			return "", s.errorf("invalid character %q in quoted-string", c)
+		default:
+			b.WriteByte(c)
+			s.pos++
+		}
+	}
+
+	s.pos = start
+	return "", s.errorf("unterminated quoted-string")
+}
+
+// parameterValue consumes a token or a quoted-string.
+func (s *fieldScanner) parameterValue() (string, error) {
+	if !s.done() && s.peek() == '"' {
+		return s.quotedString()
+	}
+
+	if value := s.token(); value != "" {
+		return value, nil
+	}
+
+	return "", s.errorf("expected token or quoted-string")
+}
+
+// element consumes a single list element, stopping at the next top level comma.
+func (s *fieldScanner) element() (e element, err error) {
+	e.offset, e.q = s.pos, 1
+
+	// The value itself is left for the ValueParser to validate, so that
+	// it can report errors in its own terms.
+	for !s.done() && s.peek() != ';' && s.peek() != ',' {
+		if s.peek() == '"' {
+			if _, err = s.quotedString(); err != nil {
+				return
+			}
+		} else {
+			s.pos++
+		}
+	}
+
+	if err = s.checkLength(e.offset); err != nil {
+		return
+	}
+
+	valueEnd := s.pos
+	weighted := false
+	params := 0
+
+	for {
+		s.skipOWS()
+
+		if s.done() || s.peek() == ',' {
+			break
+		}
+
+		if s.peek() != ';' {
+			return e, s.errorf("unexpected character %q", s.peek())
+		}
+
+		s.pos++
+		s.skipOWS()
+
+		// Empty parameters are permitted by the grammar.
+		if s.done() || s.peek() == ';' || s.peek() == ',' {
+			continue
+		}
+
+		if params++; exceeds(params, s.limits.MaxParams) {
+			return e, &ParseError{Offset: s.pos, Err: &LimitError{"MaxParams", s.limits.MaxParams}}
+		}
+
+		nameOffset := s.pos
+		name := s.token()
+
+		if name == "" {
+			return e, s.errorf("expected parameter name")
+		}
+
+		if err := s.checkLength(nameOffset); err != nil {
+			return e, err
+		}
+
+		if s.done() || s.peek() != '=' {
+			if weighted {
+				// An accept-ext may be a bare token.
+				e.ext = append(e.ext, Param{Name: name})
+				continue
+			}
+
+			return e, s.errorf("expected '=' after parameter %q", name)
+		}
+
+		s.pos++
+		valueOffset := s.pos
+		value, err := s.parameterValue()
+
+		if err != nil {
+			return e, err
+		}
+
+		if err := s.checkLength(valueOffset); err != nil {
+			return e, err
+		}
+
+		switch {
+		case weighted:
+			e.ext = append(e.ext, Param{name, value})
+		case strings.EqualFold(name, "q"):
+			// A quoted weight is not a valid qvalue, so the raw text is checked.
+			q, ok := parseQValue(s.s[valueOffset:s.pos])
+
+			if !ok {
+				return e, &ParseError{Offset: valueOffset, Err: fmt.Errorf("invalid weight %q", value)}
+			}
+
+			e.q, weighted = q, true
+		default:
+			valueEnd = s.pos
+		}
+	}
+
+	e.value = strings.TrimRight(s.s[e.offset:valueEnd], " \t")
+
+	if e.value == "" {
+		return e, &ParseError{Offset: e.offset, Err: errors.New("missing value")}
+	}
+
+	return e, nil
+}
+
+// parseQValue parses a qvalue.
+//
+// RFC 9110 limits a qvalue to at most three digits after the decimal point,
+// but a leading "." (as in "q=.2") and additional digits are tolerated
+// since some widely deployed clients send them.
+func parseQValue(s string) (float64, bool) {
+	digits, dots := 0, 0
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return 0, false
+		}
+	}
+
+	if digits == 0 || dots > 1 {
+		return 0, false
+	}
+
+	q, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || q > 1 {
+		return 0, false
+	}
+
+	return q, true
+}
+
+// parseList splits a field value into its elements.
+//
+// Empty list elements are ignored, as required by RFC 9110, section 5.6.1.
+//
+// Syntax errors, and violations of limits, are returned as a *ParseError.
+// The limits must already have been resolved.
+func parseList(field string, limits Limits) ([]element, error) {
+	var (
+		s        = fieldScanner{s: field, limits: limits}
+		elements []element
+	)
+
+	if exceeds(len(field), limits.MaxBytes) {
+		return nil, &ParseError{Offset: limits.MaxBytes, Err: &LimitError{"MaxBytes", limits.MaxBytes}}
+	}
+
+	for {
+		s.skipOWS()
+
+		if s.done() {
+			return elements, nil
+		}
+
+		if s.peek() == ',' {
+			s.pos++
+			continue
+		}
+
+		if exceeds(len(elements)+1, limits.MaxItems) {
+			return nil, &ParseError{Index: len(elements), Offset: s.pos, Err: &LimitError{"MaxItems", limits.MaxItems}}
+		}
+
+		e, err := s.element()
+
+		if err != nil {
+			parseError := err.(*ParseError)
+			parseError.Index = len(elements)
+			parseError.Item = elementText(field, e.offset)
+			return nil, parseError
+		}
+
+		elements = append(elements, e)
+	}
+}
+
+// elementText returns the text of the list element starting at offset,
+// for use in error messages.
+func elementText(field string, offset int) string {
+	s := fieldScanner{s: field, pos: offset}
+
+	for !s.done() && s.peek() != ',' {
+		if s.peek() != '"' {
+			s.pos++
+		} else if _, err := s.quotedString(); err != nil {
+			// Unterminated, so everything that remains belongs to the element.
+			return strings.TrimRight(field[offset:], " \t")
+		}
+	}
+
+	return strings.TrimRight(field[offset:s.pos], " \t")
+}
+
+// FieldValue returns the combined field value of every field line with the given name,
+// joined with commas as described by RFC 9110, section 5.3.
+//
+// An empty string is returned if the header is absent.
+func FieldValue(h http.Header, name string) string {
+	return strings.Join(h.Values(name), ", ")
+}
+
+// addVary merges names into the Vary header of h, replacing any existing
+// field lines with a single line that lists each field name once.
+func addVary(h http.Header, names ...string) {
+	var (
+		vary []string
+		seen = map[string]bool{}
+	)
+
+	for _, name := range append(h.Values("Vary"), names...) {
+		for _, name := range strings.Split(name, ",") {
+			name = strings.Trim(name, " \t")
+			key := http.CanonicalHeaderKey(name)
+
+			if name == "" || seen[key] {
+				continue
+			}
+
+			if name == "*" {
+				// A Vary of "*" already covers every other field.
+				h.Set("Vary", "*")
+				return
+			}
+
+			seen[key] = true
+			vary = append(vary, name)
+		}
+	}
+
+	h.Set("Vary", strings.Join(vary, ", "))
+}
@@ -0,0 +1,122 @@
+package negotiate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		field   string
+		want    []element
+		wantErr bool
+	}{
+		{"", nil, false},
+		{" , ,", nil, false},
+		{"a", []element{{"a", 1, 0, nil}}, false},
+		{"a, b;q=0.5", []element{{"a", 1, 0, nil}, {"b", 0.5, 3, nil}}, false},
+		{"a ;Q=0 , b", []element{{"a", 0, 0, nil}, {"b", 1, 9, nil}}, false},
+		{`text/plain; foo="a,b"; q=0.3, */*`, []element{{`text/plain; foo="a,b"`, 0.3, 0, nil}, {"*/*", 1, 30, nil}}, false},
+		{`a;q=0.5;profile="x,y"; strict`, []element{{"a", 0.5, 0, Params{{"profile", "x,y"}, {"strict", ""}}}}, false},
+		{`text/plain;foo="a\"b;c"`, []element{{`text/plain;foo="a\"b;c"`, 1, 0, nil}}, false},
+		{"text/html;level=1;q=1.000;ext;x=y", []element{{"text/html;level=1", 1, 0, Params{{"ext", ""}, {"x", "y"}}}}, false},
+		{"text/html;;q=0.1", []element{{"text/html", 0.1, 0, nil}}, false},
+		{"*; q=.2, */*; q=.2", []element{{"*", 0.2, 0, nil}, {"*/*", 0.2, 9, nil}}, false},
+		{"i like waffles.", []element{{"i like waffles.", 1, 0, nil}}, false},
+
+		{";q=1", nil, true},
+		{"a;q=2", nil, true},
+		{"a;q=", nil, true},
+		{`a;q="0.5"`, nil, true},
+		{"a;q=0.5.0", nil, true},
+		{"a;b", nil, true},
+		{"a;b=", nil, true},
+		{"a;=b", nil, true},
+		{"a;b=c d", nil, true},
+		{`a;b="c`, nil, true},
+		{"a;b=\"\x01\"", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, err := parseList(tt.field, DefaultLimits)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseList(%q) error = %v, wantErr %v", tt.field, err, tt.wantErr)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseList(%q) = %v, want %v", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	h := http.Header{}
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json;q=0.5")
+
+	if got, want := FieldValue(h, "accept"), "text/html, application/json;q=0.5"; got != want {
+		t.Errorf("FieldValue() = %q, want %q", got, want)
+	}
+
+	if got := FieldValue(h, "Accept-Language"); got != "" {
+		t.Errorf("FieldValue() = %q, want empty", got)
+	}
+}
+
+func TestAddVary(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		add      []string
+		want     []string
+	}{
+		{"empty", nil, []string{"Accept"}, []string{"Accept"}},
+		{"duplicate", []string{"Accept"}, []string{"accept"}, []string{"Accept"}},
+		{"merged", []string{"Origin, Accept", "Accept-Encoding"}, []string{"Accept-Language", "Accept"}, []string{"Origin, Accept, Accept-Encoding, Accept-Language"}},
+		{"wildcard", []string{"*"}, []string{"Accept"}, []string{"*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for _, v := range tt.existing {
+				h.Add("Vary", v)
+			}
+
+			addVary(h, tt.add...)
+
+			if got := h.Values("Vary"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("addVary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_fieldLines(t *testing.T) {
+	var item string
+
+	handler := ContentTypeMiddleware("text/html", "application/json")(
+		LanguageMiddleware("en", "fr")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			item = ContentType(r)
+		})))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Add("Accept", "text/html;q=0.5")
+	r.Header.Add("Accept", "application/json")
+
+	w := httptest.NewRecorder()
+	w.Header().Set("Vary", "Accept")
+	handler.ServeHTTP(w, r)
+
+	if item != "application/json" {
+		t.Errorf("ContentType() = %q, want %q", item, "application/json")
+	}
+
+	if got, want := w.Header().Values("Vary"), []string{"Accept, Accept-Language"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Vary = %q, want %q", got, want)
+	}
+}
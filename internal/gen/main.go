@@ -0,0 +1,116 @@
+// Command gen generates likely.go, which holds the likely script of each language
+// from the CLDR likely subtags data packaged by golang.org/x/text/language.
+//
+// It is a separate module so that the package itself has no dependencies. From this directory, run:
+//
+//	go run . > ../../likely.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+func main() {
+	var languages, regions []string
+
+	for _, code := range codes("abcdefghijklmnopqrstuvwxyz", 2, 3) {
+		// Deprecated codes are replaced before the table is consulted, so only canonical ones are listed.
+		if base, err := language.ParseBase(code); err == nil && base.String() == code && code != "und" {
+			languages = append(languages, code)
+		}
+	}
+
+	// The data only has entries for countries; anything else x/text reports for a region is inferred.
+	for _, code := range codes("ABCDEFGHIJKLMNOPQRSTUVWXYZ", 2) {
+		if region, err := language.ParseRegion(code); err == nil && region.String() == code &&
+			region.IsCountry() && region.Canonicalize() == region {
+			regions = append(regions, code)
+		}
+	}
+
+	scripts := map[string]string{}
+
+	for _, lang := range languages {
+		script, ok := likelyScript(lang)
+
+		if !ok {
+			continue
+		}
+
+		scripts[lang] = script
+
+		for _, region := range regions {
+			if s, ok := likelyScript(lang + "-" + region); ok && s != script {
+				scripts[lang+"-"+region] = s
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(scripts))
+
+	for key := range scripts {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by internal/gen from CLDR %s; DO NOT EDIT.\n\n", language.CLDRVersion)
+	fmt.Fprintf(&b, "package negotiate\n\n")
+	fmt.Fprintf(&b, "// likelyScripts maps each language to its most likely script, following the likely subtags data of CLDR %s.\n", language.CLDRVersion)
+	fmt.Fprintf(&b, "// A language and region is also listed if its likely script differs from that of the language alone.\n")
+	fmt.Fprintf(&b, "var likelyScripts = map[string]string{\n")
+
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\t%q: %q,\n", key, scripts[key])
+	}
+
+	fmt.Fprintf(&b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	os.Stdout.Write(src)
+}
+
+// likelyScript returns the script that CLDR considers most likely for tag, and reports whether there is one.
+func likelyScript(tag string) (string, bool) {
+	script, confidence := language.Make(tag).Script()
+	return script.String(), confidence != language.No && script.String() != "Zzzz"
+}
+
+// codes returns every string of the given lengths made from the characters of alphabet.
+func codes(alphabet string, lengths ...int) []string {
+	var all []string
+
+	for _, n := range lengths {
+		prefixes := []string{""}
+
+		for i := 0; i < n; i++ {
+			var next []string
+
+			for _, p := range prefixes {
+				for _, c := range alphabet {
+					next = append(next, p+string(c))
+				}
+			}
+
+			prefixes = next
+		}
+
+		all = append(all, prefixes...)
+	}
+
+	return all
+}
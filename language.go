@@ -3,58 +3,374 @@ package negotiate
 import (
 	"fmt"
 	"net/http"
-	"regexp"
 	"strings"
 )
 
+// localeValue is a BCP 47 language tag, as described by RFC 5646, or the "*" language range.
+//
+// Each subtag is stored in the case recommended by RFC 5646, section 2.1.1.
 type localeValue struct {
-	language, territory string
+	// language is the primary language subtag, "*" for the wildcard,
+	// an irregular grandfathered tag in its entirety, or empty for a private use tag.
+	language string
+
+	extlang  []string
+	script   string
+	region   string
+	variants []string
+
+	// extensions holds each extension, as a singleton followed by its subtags, such as "u-ca-gregory".
+	extensions []string
+
+	// privateUse holds the private use subtags, starting with "x", such as "x-whatever".
+	privateUse string
+}
+
+// subtags returns the subtags of l, in order.
+func (l localeValue) subtags() []string {
+	var subtags []string
+
+	if l.language != "" {
+		// An irregular grandfathered tag is split in the same way as any other.
+		subtags = append(subtags, strings.Split(l.language, "-")...)
+	}
+
+	subtags = append(subtags, l.extlang...)
+
+	if l.script != "" {
+		subtags = append(subtags, l.script)
+	}
+
+	if l.region != "" {
+		subtags = append(subtags, l.region)
+	}
+
+	subtags = append(subtags, l.variants...)
+
+	for _, extension := range l.extensions {
+		subtags = append(subtags, strings.Split(extension, "-")...)
+	}
+
+	if l.privateUse != "" {
+		subtags = append(subtags, strings.Split(l.privateUse, "-")...)
+	}
+
+	return subtags
 }
 
 func (l localeValue) String() string {
-	if l.territory == "" {
-		return l.language
+	return strings.Join(l.subtags(), "-")
+}
+
+func (l localeValue) Wildcard() bool {
+	return l.language == "*"
+}
+
+// subtagCount returns the number of subtags of l, without allocating them.
+func (l localeValue) subtagCount() int {
+	n := len(l.extlang) + len(l.variants)
+
+	if l.language != "" {
+		n += 1 + strings.Count(l.language, "-")
+	}
+
+	if l.script != "" {
+		n++
+	}
+
+	if l.region != "" {
+		n++
 	}
 
-	return l.language+"-"+l.territory
+	for _, extension := range l.extensions {
+		n += 1 + strings.Count(extension, "-")
+	}
+
+	if l.privateUse != "" {
+		n += 1 + strings.Count(l.privateUse, "-")
+	}
+
+	return n
 }
 
+// Specificity counts the subtags of l.
+//
+// Ranges with regions such as "es-MX" and "es-419" (Latin America) are equally specific,
+// but Query.Find applies the narrower of two ranges that a tag satisfies first.
 func (l localeValue) Specificity() int {
 	if l.language == "*" {
 		return 0
 	}
 
-	if l.territory == "" {
+	return l.subtagCount()
+}
+
+// regionIndex returns the position of the region among the subtags of l, or -1 if it has none.
+func (l localeValue) regionIndex() int {
+	if l.region == "" {
+		return -1
+	}
+
+	if l.script != "" {
+		return len(l.extlang) + 2
+	}
+
+	return len(l.extlang) + 1
+}
+
+// depth returns the position of the last field of l that has any subtags,
+// counting the language as 0 and the private use subtags as 6.
+func (l localeValue) depth() int {
+	switch {
+	case l.privateUse != "":
+		return 6
+	case len(l.extensions) != 0:
+		return 5
+	case len(l.variants) != 0:
+		return 4
+	case l.region != "":
+		return 3
+	case l.script != "":
+		return 2
+	case len(l.extlang) != 0:
 		return 1
 	}
 
-	return 2
+	return 0
 }
 
+// Satisfies implements the basic filtering scheme of RFC 4647, section 3.3.1:
+// the reference satisfies the tag if it is "*", or if it is a prefix of the tag
+// that ends on a subtag boundary.
+//
+// A region in the reference also matches any region it contains, according to UN M.49,
+// so that "es-419" is satisfied by "es-MX".
 func (l localeValue) Satisfies(_ref Value) bool {
 	ref := _ref.(localeValue)
 
-	if ref.language != "*" && l.language != ref.language {
+	switch {
+	case ref.language == "*":
+		return true
+	case strings.Contains(l.language, "-") || strings.Contains(ref.language, "-"):
+		// Irregular grandfathered tags don't follow the grammar, so only their subtags can be compared.
+		return subtagsPrefix(l.subtags(), ref.subtags())
+	case l.language != ref.language:
+		return false
+	}
+
+	// Each field has subtags of a distinct form, so the reference is a prefix of the tag if
+	// the fields before its last are equal, and its last field is a prefix of the tag's.
+	depth := ref.depth()
+
+	return listPrefix(l.extlang, ref.extlang, depth > 1) &&
+		(l.script == ref.script || ref.script == "" && depth < 2) &&
+		(l.region == ref.region || ref.region == "" && depth < 3 || regionContains(ref.region, l.region)) &&
+		listPrefix(l.variants, ref.variants, depth > 4) &&
+		extensionsPrefix(l.extensions, ref.extensions, depth > 5) &&
+		(ref.privateUse == "" || l.privateUse == ref.privateUse || strings.HasPrefix(l.privateUse, ref.privateUse+"-"))
+}
+
+// Score implements Scorer, so that a tag can fall back to a region containing that of the reference:
+// a tag that would satisfy the reference if their regions were equal, and whose region contains that
+// of the reference, partly satisfies it with the same score as for Closest,
+// so "es-419" (Latin America) scores 0.995 for "es-MX".
+//
+// Otherwise, the score is 1 if the tag satisfies the reference, or 0.
+func (l localeValue) Score(_ref Value) float64 {
+	if l.Satisfies(_ref) {
+		return 1
+	}
+
+	ref := _ref.(localeValue)
+
+	if l.region != "" && ref.region != "" && regionContains(l.region, ref.region) {
+		if ref.region = l.region; l.Satisfies(ref) {
+			return closestContaining
+		}
+	}
+
+	return 0
+}
+
+// listPrefix reports whether prefix is a prefix of list, or equal to it if whole is true.
+func listPrefix(list, prefix []string, whole bool) bool {
+	if len(prefix) > len(list) || whole && len(prefix) != len(list) {
 		return false
 	}
 
-	if ref.territory != "" && l.territory != ref.territory {
+	for i := range prefix {
+		if list[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// extensionsPrefix reports whether the subtags of the extensions prefix are a prefix of those of extensions,
+// or equal to them if whole is true.
+func extensionsPrefix(extensions, prefix []string, whole bool) bool {
+	if len(prefix) == 0 {
+		return !whole || len(extensions) == 0
+	}
+
+	last := len(prefix) - 1
+
+	if !listPrefix(extensions, prefix[:last], false) || len(extensions) <= last {
 		return false
 	}
 
+	if extension := extensions[last]; extension != prefix[last] {
+		return !whole && strings.HasPrefix(extension, prefix[last]+"-")
+	}
+
+	return !whole || len(extensions) == len(prefix)
+}
+
+// subtagsPrefix reports whether prefix is a prefix of tag, ignoring case.
+func subtagsPrefix(tag, prefix []string) bool {
+	return len(prefix) <= len(tag) && subtagsEqual(tag[:len(prefix)], prefix)
+}
+
+// irregularTags maps the irregular grandfathered tags of RFC 5646, which don't follow its grammar,
+// from lower case to their recommended case.
+var irregularTags = map[string]string{}
+
+func init() {
+	for _, tag := range []string{
+		"en-GB-oed", "i-ami", "i-bnn", "i-default", "i-enochian", "i-hak", "i-klingon", "i-lux", "i-mingo",
+		"i-navajo", "i-pwn", "i-tao", "i-tay", "i-tsu", "sgn-BE-FR", "sgn-BE-NL", "sgn-CH-DE",
+	} {
+		irregularTags[strings.ToLower(tag)] = tag
+	}
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigit(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphanum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
 	return true
 }
 
-var reLocale = regexp.MustCompile(`^(\*|[a-zA-Z0-9_]+)(?:-([a-zA-Z0-9_]+))?$`)
+// parseTag parses a well-formed language tag, following the grammar of RFC 5646, section 2.1.
+func parseTag(tag string) (l localeValue, ok bool) {
+	if irregular, ok := irregularTags[tag]; ok {
+		return localeValue{language: irregular}, true
+	}
+
+	subtags := strings.Split(tag, "-")
+
+	for _, subtag := range subtags {
+		if len(subtag) < 1 || len(subtag) > 8 || !isAlphanum(subtag) {
+			return l, false
+		}
+	}
+
+	i := 0
+
+	// A tag consisting only of private use subtags.
+	if subtags[0] != "x" {
+		if len(subtags[0]) < 2 || !isAlpha(subtags[0]) {
+			return l, false
+		}
+
+		l.language = subtags[0]
+		i++
+
+		for len(subtags[0]) <= 3 && i < len(subtags) && len(l.extlang) < 3 && len(subtags[i]) == 3 && isAlpha(subtags[i]) {
+			l.extlang = append(l.extlang, subtags[i])
+			i++
+		}
+
+		if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+			l.script = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+			i++
+		}
+
+		if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && isDigit(subtags[i]))) {
+			l.region = strings.ToUpper(subtags[i])
+			i++
+		}
+
+		for i < len(subtags) && (len(subtags[i]) >= 5 || (len(subtags[i]) == 4 && isDigit(subtags[i][:1]))) {
+			l.variants = append(l.variants, subtags[i])
+			i++
+		}
 
-// ParseLocale parses a locale and returns a Value.
+		for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+			start := i
+			i++
+
+			for i < len(subtags) && len(subtags[i]) >= 2 {
+				i++
+			}
+
+			if i == start+1 {
+				return l, false
+			}
+
+			l.extensions = append(l.extensions, strings.Join(subtags[start:i], "-"))
+		}
+	}
+
+	if i < len(subtags) {
+		if subtags[i] != "x" || i == len(subtags)-1 {
+			return l, false
+		}
+
+		l.privateUse = strings.Join(subtags[i:], "-")
+	}
+
+	return l, true
+}
+
+// ParseLocale parses a BCP 47 language tag, as described by RFC 5646, and returns a Value.
+//
+// The tag is parsed into its language, extended language, script, region, variant, extension
+// and private use subtags, which are normalized to the case recommended by RFC 5646.
+// For compatibility with locale identifiers, an underscore is accepted in place of a hyphen.
+//
+// Tags are matched using the basic filtering scheme of RFC 4647,
+// so that "zh-Hant" is satisfied by "zh-Hant-TW", but "zh-TW" is not.
+// Regions are matched according to UN M.49 containment, so "es-419" (Latin America) is satisfied by "es-MX",
+// and "en-001" (the world) by any English tag with a region.
+// In the other direction, a tag with a region containing that of the range partly satisfies it
+// (see Scorer), so "es-MX" falls back to "es-419" when no tag for Mexico is offered.
 func ParseLocale(locale string) (Value, error) {
-	match := reLocale.FindStringSubmatch(locale)
-	if match == nil {
+	if locale == "*" {
+		return localeValue{language: "*"}, nil
+	}
+
+	l, ok := parseTag(strings.ToLower(strings.Replace(locale, "_", "-", -1)))
+
+	if !ok {
 		return nil, fmt.Errorf("bad locale: %q", locale)
 	}
 
-	return localeValue{strings.ToLower(match[1]), strings.ToUpper(match[2])}, nil
+	return l, nil
 }
 
 // LanguageMiddleware is shorthand for Middleware("Accept-Language", ParseLocale, items...)
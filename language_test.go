@@ -3,6 +3,7 @@ package negotiate
 import (
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -51,18 +52,37 @@ func ExampleLanguage() {
 
 func TestParseLocale(t *testing.T) {
 	tests := []struct {
-		locale  string
-		wantString string
+		locale          string
+		wantString      string
 		wantSpecificity int
-		wantErr bool
+		wantErr         bool
 	}{
 		{"*", "*", 0, false},
 		{"en", "en", 1, false},
 		{"EN", "en", 1, false},
 		{"en-ca", "en-CA", 2, false},
 		{"EN-CA", "en-CA", 2, false},
+		{"zh-hant-tw", "zh-Hant-TW", 3, false},
+		{"SR-LATN", "sr-Latn", 2, false},
+		{"de-ch-1996", "de-CH-1996", 3, false},
+		{"en-US-u-ca-gregory", "en-US-u-ca-gregory", 5, false},
+		{"es-419", "es-419", 2, false},
+		{"zh-yue-HK", "zh-yue-HK", 3, false},
+		{"sl-rozaj-biske-1994", "sl-rozaj-biske-1994", 4, false},
+		{"en-a-bbb-x-a-ccc", "en-a-bbb-x-a-ccc", 6, false},
+		{"x-whatever", "x-whatever", 2, false},
+		{"I-KLINGON", "i-klingon", 2, false},
+		{"en-gb-oed", "en-GB-oed", 3, false},
+		{"en_US", "en-US", 2, false},
 		{"", "", 0, true},
 		{"what is this", "", 0, true},
+		{"en-", "", 0, true},
+		{"e", "", 0, true},
+		{"abcdefghi", "", 0, true},
+		{"en-u", "", 0, true},
+		{"en-x", "", 0, true},
+		{"en-US-419", "", 0, true},
+		{"1en", "", 0, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.locale, func(t *testing.T) {
@@ -85,3 +105,102 @@ func TestParseLocale(t *testing.T) {
 		})
 	}
 }
+
+func TestLocaleValue_Satisfies(t *testing.T) {
+	tests := []struct {
+		tag, ref string
+		want     bool
+	}{
+		{"en", "*", true},
+		{"zh-Hant-TW", "zh", true},
+		{"zh-Hant-TW", "zh-Hant", true},
+		{"zh-Hant-TW", "zh-TW", false},
+		{"zh-Hant", "zh-Hant-TW", false},
+		{"de-CH-1996", "de-CH", true},
+		{"en-US-u-ca-gregory", "en-US", true},
+		{"en", "en-US", false},
+		{"ena", "en", false},
+		{"es-MX", "es-419", true},
+		{"es-AR-u-nu-latn", "es-419", true},
+		{"es-ES", "es-419", false},
+		{"es-419", "es-MX", false},
+		{"fr-FR", "fr-150", true},
+		{"en-AU", "en-001", true},
+		{"en", "en-001", false},
+		{"zh-yue-HK", "zh-yue", true},
+		{"zh-yue-HK", "zh-HK", false},
+		{"sl-rozaj-biske", "sl-rozaj", true},
+		{"sl-rozaj-biske", "sl-biske", false},
+		{"en-a-bbb-ccc-x-foo", "en-a-bbb", true},
+		{"en-a-bbb-ccc", "en-a-bbb-cc", false},
+		{"en-a-bbb-b-ccc", "en-a-bbb-b-ccc", true},
+		{"en-a-bbb-ccc-b-ddd", "en-a-bbb-b-ddd", false},
+		{"en-x-foo-bar", "en-x-foo", true},
+		{"en-US-x-foo", "en-x-foo", false},
+		{"x-foo-bar", "x-foo", true},
+		{"sgn-BE-FR", "sgn-BE", true},
+		{"sgn-BE-FR", "sgn-BE-NL", false},
+	}
+	for _, tt := range tests {
+		if got := Must(ParseLocale(tt.tag)).Satisfies(Must(ParseLocale(tt.ref))); got != tt.want {
+			t.Errorf("%s.Satisfies(%s) = %t, want %t", tt.tag, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageRegionPrecedence(t *testing.T) {
+	tests := []struct {
+		query, item string
+		want        bool
+	}{
+		// An exact region takes precedence over a region containing it, whichever order they are sent in.
+		{"es-419, es-MX;q=0", "es-MX", false},
+		{"es-MX;q=0, es-419", "es-MX", false},
+		{"es-419, es-MX;q=0", "es-MX-x-foo", false},
+		{"es-001, es-419;q=0", "es-MX", false},
+		{"es-419;q=0, es-001", "es-AR", false},
+		{"es-419;q=0, es-001", "es-ES", true},
+		{"es-MX, es-419;q=0", "es-MX", true},
+	}
+	for _, tt := range tests {
+		_, err := Make(ParseLocale, tt.item).Process(tt.query)
+
+		if got := err == nil; got != tt.want {
+			t.Errorf("Process(%q) with %s: acceptable = %t, want %t (%v)", tt.query, tt.item, got, tt.want, err)
+		}
+	}
+}
+
+func TestLocaleValue_Score(t *testing.T) {
+	tests := []struct {
+		query string
+		items []string
+		want  string
+		q     float64
+	}{
+		// A country falls back to a region containing it.
+		{"es-MX", []string{"es-419"}, "es-419", closestContaining},
+		{"es-MX", []string{"es-419", "es-MX"}, "es-MX", 1},
+		{"en-US", []string{"en-150", "en-001"}, "en-001", closestContaining},
+		{"es-MX, es-419;q=0.5", []string{"es-419"}, "es-419", 0.5},
+
+		// The other subtags must still match.
+		{"es-Latn-MX", []string{"es-419"}, "", 0},
+		{"es-MX", []string{"es-ES"}, "", 0},
+	}
+	for _, tt := range tests {
+		alternatives, _ := Make(ParseLocale, tt.items...).Rank(tt.query)
+
+		if len(alternatives) == 0 {
+			if tt.want != "" {
+				t.Errorf("Rank(%q) with %q found nothing, want %s", tt.query, tt.items, tt.want)
+			}
+
+			continue
+		}
+
+		if got := alternatives[0]; got.Item != tt.want || math.Abs(got.Q-tt.q) > 1e-9 {
+			t.Errorf("Rank(%q) with %q = %s q=%g, want %s q=%g", tt.query, tt.items, got.Item, got.Q, tt.want, tt.q)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package negotiate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Limits bounds the work done parsing a query, to protect against hostile headers.
+//
+// A zero field takes its value from DefaultLimits, and a negative field disables that limit.
+type Limits struct {
+	// MaxBytes is the maximum length of the query in bytes.
+	MaxBytes int
+
+	// MaxItems is the maximum number of items in the query.
+	MaxItems int
+
+	// MaxParams is the maximum number of parameters on a single item,
+	// including its weight and any extension parameters.
+	MaxParams int
+
+	// MaxValueLength is the maximum length in bytes of an item's value,
+	// or of the name or value of any of its parameters.
+	MaxValueLength int
+}
+
+// DefaultLimits are the limits used by ParseQuery, and for any zero fields of a Limits.
+//
+// They are generous compared to what browsers and HTTP libraries send.
+var DefaultLimits = Limits{
+	MaxBytes:       4096,
+	MaxItems:       64,
+	MaxParams:      16,
+	MaxValueLength: 256,
+}
+
+// resolve replaces the zero fields of l with their defaults.
+func (l Limits) resolve() Limits {
+	if l.MaxBytes == 0 {
+		l.MaxBytes = DefaultLimits.MaxBytes
+	}
+
+	if l.MaxItems == 0 {
+		l.MaxItems = DefaultLimits.MaxItems
+	}
+
+	if l.MaxParams == 0 {
+		l.MaxParams = DefaultLimits.MaxParams
+	}
+
+	if l.MaxValueLength == 0 {
+		l.MaxValueLength = DefaultLimits.MaxValueLength
+	}
+
+	return l
+}
+
+// exceeds returns true if n is over a resolved limit.
+func exceeds(n, limit int) bool {
+	return limit >= 0 && n > limit
+}
+
+// ErrLimitExceeded is matched by every *LimitError using errors.Is.
+var ErrLimitExceeded = errors.New("query exceeds parsing limits")
+
+// LimitError is returned, wrapped in a *ParseError, when a query exceeds one of its Limits.
+type LimitError struct {
+	// Limit is the name of the field of Limits that was exceeded, such as "MaxBytes".
+	Limit string
+
+	// Max is the value of the limit that was exceeded.
+	Max int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("query exceeds %s limit of %d", e.Limit, e.Max)
+}
+
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
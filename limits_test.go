@@ -0,0 +1,69 @@
+package negotiate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseQueryLimits(t *testing.T) {
+	limits := Limits{MaxBytes: 64, MaxItems: 3, MaxParams: 2, MaxValueLength: 8}
+
+	tests := []struct {
+		query     string
+		wantLimit string
+	}{
+		{"a, b, c", ""},
+		{"a;x=1;q=0.5", ""},
+		{"a, b, c, d", "MaxItems"},
+		{"a, , b, ,c, ", ""},
+		{"a;x=1;y=2;q=0.5", "MaxParams"},
+		{"abcdefghi", "MaxValueLength"},
+		{"a;abcdefghi=1", "MaxValueLength"},
+		{`a;x="abcdefghi"`, "MaxValueLength"},
+		{strings.Repeat(" ", 60) + "abcde", "MaxBytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQueryLimits(ParseSimple, tt.query, limits)
+
+			var limitError *LimitError
+			if errors.As(err, &limitError) != (tt.wantLimit != "") {
+				t.Fatalf("ParseQueryLimits(%q) error = %v, want limit %q", tt.query, err, tt.wantLimit)
+			}
+
+			if tt.wantLimit != "" && (limitError.Limit != tt.wantLimit || !errors.Is(err, ErrLimitExceeded)) {
+				t.Errorf("ParseQueryLimits(%q) error = %v, want limit %q", tt.query, err, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestParseQueryLimits_disabled(t *testing.T) {
+	query := strings.Repeat("a, ", 1000) + "b"
+
+	if _, err := ParseQuery(ParseSimple, query); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("ParseQuery() error = %v, want ErrLimitExceeded", err)
+	}
+
+	if _, err := ParseQueryLimits(ParseSimple, query, Limits{MaxBytes: -1, MaxItems: -1}); err != nil {
+		t.Errorf("ParseQueryLimits() error = %v", err)
+	}
+}
+
+func TestMiddleware_limits(t *testing.T) {
+	handler := MiddlewareFor("Accept-Encoding", Make(ParseSimple, "gzip").WithLimits(Limits{MaxItems: 2}))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "br, deflate, gzip")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestHeaderFieldsTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestHeaderFieldsTooLarge)
+	}
+}
@@ -0,0 +1,379 @@
+package negotiate
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+)
+
+// LanguageMatching selects one of the schemes of RFC 4647 for matching language tags against language ranges.
+type LanguageMatching int
+
+const (
+	// BasicFiltering is the basic filtering scheme of RFC 4647, section 3.3.1, as used by ParseLocale.
+	// A range is satisfied by any tag that it is a prefix of, so "de" is satisfied by "de-AT", but not the other way around.
+	// Regions are compared using UN M.49 containment in both directions, as described for ParseLocale.
+	BasicFiltering LanguageMatching = iota
+
+	// ExtendedFiltering is the extended filtering scheme of RFC 4647, section 3.3.2.
+	// It permits ranges with a wildcard in place of any subtag, so that "de-*-DE" is satisfied
+	// by "de-DE" and "de-Latn-DE" alike. Subtags that the range doesn't mention are skipped over,
+	// so "de-DE" is also satisfied by "de-Latn-DE".
+	//
+	// Filtering is most useful with Negotiate.Rank, to collect every item that satisfies the query.
+	//
+	// Unlike the other schemes, regions are compared literally, without UN M.49 containment,
+	// since a range may have a wildcard in place of the region.
+	ExtendedFiltering
+
+	// Lookup is the lookup scheme of RFC 4647, section 3.4.
+	// Each range is progressively truncated until it equals one of the items,
+	// so "de-AT" is satisfied by "de", and "en-US" is satisfied by "en" but not "en-GB".
+	// Regions are compared using UN M.49 containment in both directions: "es-419" (Latin America)
+	// is satisfied by "es-MX", as with BasicFiltering, and "es-MX" falls back to "es-419".
+	//
+	// Its values implement Scorer. A tag that had to be reached by truncation scores 0.99 for each subtag removed,
+	// and one with a region containing that of the range scores 0.995, so only satisfies the range partly.
+	// A range that the tag fully satisfies decides its weight instead, as with "de-AT, de;q=0", which excludes "de".
+	//
+	// The ranges are tried in order of quality, rather than specificity, and for a given range
+	// the item requiring the least truncation is preferred.
+	// A "*" range is satisfied by anything, but is only considered after every other range.
+	Lookup
+
+	// Closest extends BasicFiltering by also accepting tags of the same language that differ from the range
+	// in their script or region, with a reduced quality, in the manner of CLDR language matching.
+	// So a query for "pt-PT" prefers "pt" to "pt-BR", but prefers either to a 406.
+	//
+	// Regions are compared using UN M.49 containment, so "es-MX" prefers "es-419" (Latin America) to "es",
+	// and "es-AR" to "es-ES".
+	//
+	// Its values implement Scorer. Compared to the range, a tag scores 0.99 for each subtag it lacks,
+	// 0.995 for a region containing that of the range, 0.98 for a different region in the same part of the world,
+	// 0.96 for any other region, and 0.5 for a different script, with the scores multiplied together.
+	// A tag that fully satisfies a range, as with BasicFiltering, takes the weight of that range,
+	// rather than that of a close match to another.
+	Closest
+)
+
+// The scores given by Closest to a tag for each way in which it differs from a language range.
+const (
+	// closestTruncated is the score of a tag lacking a subtag that the range has, as with Lookup.
+	closestTruncated = 0.99
+
+	// closestContaining is the score of a tag with a region containing that of the range, such as "es-419" for "es-MX".
+	// It is more specific than a tag without a region, so scores higher.
+	closestContaining = 0.995
+
+	// closestRelated is the score of a tag with a different region in the same part of the world,
+	// such as "es-AR" for "es-MX".
+	closestRelated = 0.98
+
+	// closestRegion is the score of a tag with a different region, such as "pt-BR" for "pt-PT".
+	// Regional variants of a language are generally mutually intelligible.
+	closestRegion = 0.96
+
+	// closestScript is the score of a tag with a different script, such as "sr-Latn" for "sr-Cyrl".
+	closestScript = 0.5
+)
+
+// matchingValue is a language tag or range, matched using a LanguageMatching other than BasicFiltering.
+type matchingValue struct {
+	// tag is the parsed form of the value, if it isn't an extended range.
+	tag localeValue
+
+	// subtags holds the subtags of the tag, normalized as for ParseLocale.
+	// The subtags of an extended range may include "*".
+	subtags  []string
+	matching LanguageMatching
+}
+
+func (v matchingValue) String() string {
+	return strings.Join(v.subtags, "-")
+}
+
+// matchesAll reports whether v is the "*" range.
+func (v matchingValue) matchesAll() bool {
+	return len(v.subtags) == 1 && v.subtags[0] == "*"
+}
+
+// Wildcard reports whether v is the "*" range, or an extended range with a "*" subtag.
+func (v matchingValue) Wildcard() bool {
+	for _, subtag := range v.subtags {
+		if subtag == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v matchingValue) Specificity() int {
+	if v.matchesAll() {
+		return 0
+	}
+
+	switch v.matching {
+	case Lookup:
+		// Lookup considers ranges in order of quality,
+		// so every range other than the wildcard has the same precedence.
+		return 1
+	}
+
+	specificity := 0
+
+	for _, subtag := range v.subtags {
+		if subtag != "*" {
+			specificity++
+		}
+	}
+
+	return specificity
+}
+
+func (v matchingValue) Satisfies(_ref Value) bool {
+	ref := _ref.(matchingValue)
+
+	if ref.matchesAll() {
+		return true
+	}
+
+	switch v.matching {
+	case Lookup, Closest:
+		return v.Score(ref) > 0
+	default:
+		return extendedFilterMatches(v.subtags, ref.subtags)
+	}
+}
+
+// Score implements Scorer. Only Lookup and Closest give partial scores.
+func (v matchingValue) Score(_ref Value) float64 {
+	ref := _ref.(matchingValue)
+
+	if v.matching == Lookup {
+		if ref.matchesAll() {
+			return 1
+		}
+
+		return lookupScore(v, ref)
+	}
+
+	if v.matching != Closest {
+		if v.Satisfies(ref) {
+			return 1
+		}
+
+		return 0
+	}
+
+	if ref.matchesAll() || v.tag.Satisfies(ref.tag) {
+		return 1
+	}
+
+	// Private use and irregular tags can only match using basic filtering.
+	if v.tag.language == "" || strings.Contains(v.tag.language, "-") ||
+		!strings.EqualFold(v.tag.language, ref.tag.language) || !subtagsEqual(v.tag.extlang, ref.tag.extlang) {
+		return 0
+	}
+
+	score := subtagScore(v.tag.script, ref.tag.script, closestScript) *
+		regionScore(v.tag.region, ref.tag.region)
+
+	if len(ref.tag.variants) != 0 && !subtagsEqual(v.tag.variants, ref.tag.variants) {
+		score *= closestTruncated
+	}
+
+	return score
+}
+
+// subtagScore returns the score given by Closest to a tag with the given subtag,
+// compared to a range with the subtag ref.
+func subtagScore(subtag, ref string, mismatch float64) float64 {
+	switch {
+	case ref == "" || strings.EqualFold(subtag, ref):
+		return 1
+	case subtag == "":
+		return closestTruncated
+	default:
+		return mismatch
+	}
+}
+
+// regionScore returns the score given by Closest to a tag with the given region,
+// compared to a range with the region ref.
+func regionScore(region, ref string) float64 {
+	switch {
+	case ref == "" || regionContains(ref, region):
+		return 1
+	case region == "":
+		return closestTruncated
+	case regionContains(region, ref):
+		return closestContaining
+	case regionsRelated(region, ref):
+		return closestRelated
+	default:
+		return closestRegion
+	}
+}
+
+// lookupScore returns the score given by Lookup to tag against rng: 1 if they are equal,
+// multiplied by closestTruncated for each subtag that the lookup scheme of RFC 4647, section 3.4,
+// removes from rng to make them equal, or 0 if it never does.
+//
+// The region of rng is taken to be equal to any region of tag that it contains, as with BasicFiltering,
+// and to partly equal any region of tag that contains it, with a score of closestContaining.
+func lookupScore(tag, rng matchingValue) float64 {
+	n := len(tag.subtags)
+
+	// A truncation never ends with a singleton.
+	if n == 0 || n > len(rng.subtags) || len(rng.subtags[n-1]) == 1 {
+		return 0
+	}
+
+	score, region := 1.0, rng.tag.regionIndex()
+
+	for i := 0; i < n; i++ {
+		switch {
+		case strings.EqualFold(tag.subtags[i], rng.subtags[i]):
+		case i != region || i != tag.tag.regionIndex():
+			return 0
+		case regionContains(rng.tag.region, tag.tag.region):
+		case regionContains(tag.tag.region, rng.tag.region):
+			score = closestContaining
+		default:
+			return 0
+		}
+	}
+
+	return score * math.Pow(closestTruncated, float64(len(rng.subtags)-n))
+}
+
+// extendedFilterMatches implements the extended filtering algorithm of RFC 4647, section 3.3.2.
+func extendedFilterMatches(tag, rng []string) bool {
+	if len(tag) == 0 || (rng[0] != "*" && !strings.EqualFold(rng[0], tag[0])) {
+		return false
+	}
+
+	i, j := 1, 1
+
+	for j < len(rng) {
+		switch {
+		case rng[j] == "*":
+			j++
+		case i >= len(tag):
+			return false
+		case strings.EqualFold(rng[j], tag[i]):
+			i++
+			j++
+		case len(tag[i]) == 1:
+			return false
+		default:
+			i++
+		}
+	}
+
+	return true
+}
+
+func subtagsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Parser returns a ValueParser for language tags that are matched using m.
+//
+// For BasicFiltering, this is ParseLocale.
+// For ExtendedFiltering, the parser also accepts extended language ranges such as "de-*-DE".
+func (m LanguageMatching) Parser() ValueParser {
+	if m == BasicFiltering {
+		return ParseLocale
+	}
+
+	return func(locale string) (Value, error) {
+		if locale == "*" {
+			return matchingValue{localeValue{language: "*"}, []string{"*"}, m}, nil
+		}
+
+		if l, err := ParseLocale(locale); err == nil {
+			return matchingValue{l.(localeValue), l.(localeValue).subtags(), m}, nil
+		}
+
+		if m == ExtendedFiltering {
+			if subtags, ok := parseExtendedRange(locale); ok {
+				return matchingValue{subtags: subtags, matching: m}, nil
+			}
+		}
+
+		return nil, fmt.Errorf("bad locale: %q", locale)
+	}
+}
+
+// parseExtendedRange parses an extended-language-range, as described by RFC 4647, section 2.2.
+func parseExtendedRange(rng string) ([]string, bool) {
+	subtags := strings.Split(strings.ToLower(rng), "-")
+
+	for i, subtag := range subtags {
+		switch {
+		case subtag == "*":
+		case len(subtag) < 1 || len(subtag) > 8:
+			return nil, false
+		case i == 0 && !isAlpha(subtag), !isAlphanum(subtag):
+			return nil, false
+		}
+	}
+
+	return subtags, true
+}
+
+// LookupPreference is the Strategy used with Lookup.
+//
+// It is like ClientPreference, except that the quality given by the client to each range, along with
+// the source quality of the item, is compared before the reduction in quality from truncating the range.
+// So ranges are tried in order of quality, and between candidates satisfying the same range,
+// the one requiring the least truncation is preferred.
+func LookupPreference(a, b Candidate) bool {
+	if qa, qb := a.Match.Q*sourceQuality(a.Value), b.Match.Q*sourceQuality(b.Value); qa != qb {
+		return qa > qb
+	}
+
+	if a.Entry != b.Entry {
+		return a.Entry < b.Entry
+	}
+
+	if a.Q != b.Q {
+		return a.Q > b.Q
+	}
+
+	return a.Choice < b.Choice
+}
+
+// MakeLanguage returns a Negotiate for language tags that are matched using m.
+//
+// This function will panic if any of the passed items fail to parse.
+func MakeLanguage(m LanguageMatching, items ...string) Negotiate {
+	n := Make(m.Parser(), items...)
+
+	if m == Lookup {
+		n = n.WithStrategy(LookupPreference)
+	}
+
+	return n
+}
+
+// LanguageMatchingMiddleware is like LanguageMiddleware, but matches language tags using m.
+//
+// Lookup is often combined with a fallback Policy, since RFC 4647 expects lookup to return a default
+// when nothing matches; use MiddlewareFor("Accept-Language", MakeLanguage(Lookup, items...), WithPolicy(FallbackFirst)).
+func LanguageMatchingMiddleware(m LanguageMatching, items ...string) func(http.Handler) http.Handler {
+	return MiddlewareFor("Accept-Language", MakeLanguage(m, items...))
+}
@@ -0,0 +1,188 @@
+package negotiate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleLanguageMatching() {
+	items := []string{"en-GB", "en", "de", "de-Latn-DE"}
+
+	for _, m := range []struct {
+		name     string
+		matching LanguageMatching
+	}{
+		{"BasicFiltering", BasicFiltering},
+		{"ExtendedFiltering", ExtendedFiltering},
+		{"Lookup", Lookup},
+		{"Closest", Closest},
+	} {
+		negotiate := MakeLanguage(m.matching, items...)
+
+		for _, query := range []string{"en-US", "de-AT", "de-DE"} {
+			alternatives, err := negotiate.Rank(query)
+
+			var matches []string
+			for _, alternative := range alternatives {
+				matches = append(matches, alternative.Item)
+			}
+
+			fmt.Printf("%-17s %s -> %v %v\n", m.name, query, matches, err)
+		}
+	}
+
+	// Output:
+	// BasicFiltering    en-US -> [] no item satisfies query
+	// BasicFiltering    de-AT -> [] no item satisfies query
+	// BasicFiltering    de-DE -> [] no item satisfies query
+	// ExtendedFiltering en-US -> [] no item satisfies query
+	// ExtendedFiltering de-AT -> [] no item satisfies query
+	// ExtendedFiltering de-DE -> [de-Latn-DE] <nil>
+	// Lookup            en-US -> [en] <nil>
+	// Lookup            de-AT -> [de] <nil>
+	// Lookup            de-DE -> [de] <nil>
+	// Closest           en-US -> [en en-GB] <nil>
+	// Closest           de-AT -> [de de-Latn-DE] <nil>
+	// Closest           de-DE -> [de-Latn-DE de] <nil>
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		query string
+		items []string
+		want  string
+	}{
+		// The example from RFC 4647, section 3.4.
+		{"zh-Hant-CN-x-private1-private2", []string{"zh", "zh-Hant", "zh-Hant-CN-x-private1"}, "zh-Hant-CN-x-private1"},
+		{"zh-Hant-CN-x-private1-private2", []string{"zh", "zh-Hant"}, "zh-Hant"},
+		{"zh-Hant-CN-x-private1-private2", []string{"zh", "zh-CN"}, "zh"},
+		{"zh-Hant-CN-x-private1-private2", []string{"zh-Hant-CN", "zh"}, "zh-Hant-CN"},
+
+		// Ranges are tried in order of quality, not specificity.
+		{"de-AT;q=0.5, de", []string{"de-AT", "de"}, "de"},
+		{"de-AT, de;q=0.5", []string{"de", "de-AT"}, "de-AT"},
+		{"fr-CA, en;q=0.5", []string{"en", "fr"}, "fr"},
+		{"de-AT, en", []string{"en", "de"}, "de"},
+		{"de-AT-1996, de-AT", []string{"de", "de-AT"}, "de-AT"},
+		{"*;q=0.1, fr;q=0.5", []string{"en", "fr"}, "fr"},
+		{"fr-CA;q=0.5, *", []string{"en", "fr"}, "en"},
+		{"*", []string{"en", "fr"}, "en"},
+
+		// A region can be replaced by one containing it.
+		{"es-MX", []string{"es", "es-419"}, "es-419"},
+		{"es-MX", []string{"es-ES", "es-001"}, "es-001"},
+		{"es-MX-x-foo", []string{"es-419"}, "es-419"},
+		{"en-US", []string{"en-150", "en"}, "en"},
+		{"es-MX", []string{"es-419", "es-MX"}, "es-MX"},
+
+		// A region is satisfied by the regions it contains.
+		{"es-419", []string{"es-MX"}, "es-MX"},
+		{"es-419", []string{"es", "es-AR"}, "es-AR"},
+		{"es-419, es-AR;q=0", []string{"es-AR", "es"}, "es"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := MakeLanguage(Lookup, tt.items...).Process(tt.query)
+
+			if err != nil || got != tt.want {
+				t.Errorf("Process(%q) = %q, %v, want %q", tt.query, got, err, tt.want)
+			}
+		})
+	}
+
+	// A range with a weight of 0 excludes its exact tag, even when a longer range truncates to it.
+	for _, query := range []string{"de-AT, de;q=0", "de;q=0, de-AT"} {
+		var notAcceptable *NotAcceptableError
+
+		if got, err := MakeLanguage(Lookup, "de").Process(query); !errors.As(err, &notAcceptable) {
+			t.Errorf("Process(%q) = %q, %v, want a *NotAcceptableError", query, got, err)
+		}
+	}
+}
+
+func TestExtendedFiltering(t *testing.T) {
+	// The examples from RFC 4647, section 3.3.2.
+	rng := Must(ExtendedFiltering.Parser()("de-*-DE"))
+
+	for _, tag := range []string{"de-DE", "de-de", "de-Latn-DE", "de-Latf-DE", "de-DE-x-goethe", "de-Latn-DE-1996", "de-Deva-DE"} {
+		if !Must(ExtendedFiltering.Parser()(tag)).Satisfies(rng) {
+			t.Errorf("%s does not satisfy %s", tag, rng)
+		}
+	}
+
+	for _, tag := range []string{"de", "de-x-DE", "de-Deva"} {
+		if Must(ExtendedFiltering.Parser()(tag)).Satisfies(rng) {
+			t.Errorf("%s satisfies %s", tag, rng)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	tests := []struct {
+		query string
+		items []string
+		want  string
+		q     float64
+	}{
+		{"pt-PT", []string{"pt-BR", "pt"}, "pt", closestTruncated},
+		{"pt-PT", []string{"en", "pt-BR"}, "pt-BR", closestRegion},
+		{"pt", []string{"en", "pt-BR"}, "pt-BR", 1},
+		{"en-US", []string{"en-GB", "en-AU", "en-US"}, "en-US", 1},
+		{"sr-Cyrl-RS", []string{"sr-Latn-RS", "sr-Cyrl-ME"}, "sr-Cyrl-ME", closestRelated},
+		{"sr-Cyrl", []string{"sr-Latn"}, "sr-Latn", closestScript},
+		{"de-CH-1996", []string{"de-CH"}, "de-CH", closestTruncated},
+
+		// Regions are compared using UN M.49 containment.
+		{"es-419", []string{"es-ES", "es-MX"}, "es-MX", 1},
+		{"es-MX", []string{"es", "es-419"}, "es-419", closestContaining},
+		{"es-MX", []string{"es-ES", "es-AR"}, "es-AR", closestRelated},
+		{"en-150", []string{"en-US", "en-IE"}, "en-IE", 1},
+
+		// A close match to a preferred range can beat an exact match of another tag to a less preferred one.
+		{"pt-PT, es;q=0.5", []string{"es", "pt-BR"}, "pt-BR", closestRegion},
+
+		// But a range that a tag fully satisfies decides its weight.
+		{"pt-PT, pt-BR;q=0.5", []string{"pt-BR"}, "pt-BR", 0.5},
+		{"pt-PT, pt-BR;q=0.01", []string{"pt-BR"}, "pt-BR", 0.01},
+		{"pt-PT, pt;q=0.1", []string{"pt-BR"}, "pt-BR", 0.1},
+		{"pt-PT, *;q=0.1", []string{"en", "pt-BR"}, "en", 0.1},
+
+		// A close match can't override an explicit rejection.
+		{"en-US, en-GB;q=0, fr;q=0.5", []string{"en-GB", "fr"}, "fr", 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			alternatives, err := MakeLanguage(Closest, tt.items...).Rank(tt.query)
+
+			if err != nil {
+				t.Fatalf("Rank(%q) returned error: %v", tt.query, err)
+			}
+
+			if got := alternatives[0]; got.Item != tt.want || got.Q != tt.q {
+				t.Errorf("Rank(%q)[0] = %q, %v, want %q, %v", tt.query, got.Item, got.Q, tt.want, tt.q)
+			}
+		})
+	}
+
+	for _, tt := range []struct{ query, item string }{
+		{"pt", "es"},
+		{"x-foo", "x-bar"},
+		{"i-klingon", "i-navajo"},
+		{"zh-cmn", "zh-yue"},
+	} {
+		if _, err := MakeLanguage(Closest, tt.item).Process(tt.query); err == nil {
+			t.Errorf("Process(%q) accepted %q", tt.query, tt.item)
+		}
+	}
+}
+
+func TestQuery_FindScorer(t *testing.T) {
+	// Source quality is applied on top of the score.
+	n := MakeOffers(Closest.Parser(), Offer{"pt-BR", 0.5}, Offer{"es", 1})
+	alternatives, err := n.Rank("pt-PT, es;q=0.5")
+
+	if err != nil || alternatives[0].Item != "es" || alternatives[1].Q != 0.5*closestRegion {
+		t.Errorf("Rank() = %v, %v", alternatives, err)
+	}
+}
@@ -15,6 +15,10 @@ func (m mediaValue) String() string {
 	return mime.FormatMediaType(m.major+"/"+m.minor, m.params)
 }
 
+func (m mediaValue) Wildcard() bool {
+	return m.major == "*" || m.minor == "*"
+}
+
 func (m mediaValue) Specificity() int {
 	if m.major == "*" {
 		return 0
@@ -24,11 +28,9 @@ func (m mediaValue) Specificity() int {
 		return 1
 	}
 
-	if len(m.params) == 0 {
-		return 2
-	}
-
-	return 3
+	// Each parameter makes the media type more specific,
+	// so that "text/plain;format=flowed;charset=utf-8" takes precedence over "text/plain;format=flowed".
+	return 2 + len(m.params)
 }
 
 func (m mediaValue) Satisfies(_ref Value) bool {
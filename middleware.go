@@ -2,37 +2,271 @@ package negotiate
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
+// config holds the settings made by Options, which are shared by every middleware that accepts them.
+type config struct {
+	policy Policy
+
+	onNegotiated, onNotAcceptable, onBadRequest Hook
+
+	variants bool
+}
+
+func newConfig(options []Option) config {
+	c := config{onNotAcceptable: writeError, onBadRequest: writeError}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// fail calls the hook that writes the response for the failure described by e.
+func (c config) fail(w http.ResponseWriter, r *http.Request, e Event) {
+	if errors.Is(e.Err, ErrNotAcceptable) {
+		c.onNotAcceptable(w, r, e)
+	} else {
+		c.onBadRequest(w, r, e)
+	}
+}
+
 type handler struct {
-	negotiate Negotiate
-	header    string
-	next      http.Handler
+	axes []axis
+	next http.Handler
+	config
 }
 
-type ctxKey string
+// axis is an Axis along with the result its Policy provides when nothing satisfies a request.
+type axis struct {
+	Axis
+	fallback    Result
+	canFallback bool
+}
+
+// Event describes the outcome of negotiating on a request, as passed to a Hook.
+type Event struct {
+	// Header is the canonical name of the header that was negotiated on.
+	// It is empty for VariantMiddleware and TransparentMiddleware, except when a header fails to parse.
+	Header string
+
+	// Query is the parsed query, or nil if the header failed to parse.
+	Query Query
+
+	// Items holds the items that were offered, or the names of the variants for VariantMiddleware
+	// and TransparentMiddleware. It must not be modified.
+	Items []string
+
+	// Result is the result of the negotiation, for OnNegotiated.
+	// For VariantMiddleware and TransparentMiddleware, its Item is the name of the chosen variant.
+	Result Result
+
+	// Err is the error returned by negotiating, if any.
+	// It is a *NotAcceptableError for OnNotAcceptable, and a *ParseError for OnBadRequest.
+	//
+	// If the Result is a fallback, Err holds the *NotAcceptableError the Policy recovered from.
+	Err error
 
-// Item returns the item negotiated for in a header processed by Middleware().
-func Item(r *http.Request, header string) string {
-	item, _ := r.Context().Value(ctxKey(http.CanonicalHeaderKey(header))).(string)
-	return item
+	// Failures holds an Event for every header that failed, in order, for OnNotAcceptable and OnBadRequest.
+	// The other fields describe the failure that decided the status of the response:
+	// the first that exceeded its limits, or else the first that failed to parse, or else the first with no match.
+	Failures []Event
 }
 
-func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	w.Header().Add("Vary", h.header)
-
-	switch value, err := h.negotiate.Process(r.Header.Get(h.header)); err {
-	case nil:
-		h.next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey(h.header), value)))
-	case ErrNotAcceptable:
-		http.Error(w,
-			"406: Not Acceptable\nSupported values for "+h.header+" header are: "+h.negotiate.String(),
-			http.StatusNotAcceptable)
+// Hook is called by the middleware returned by MiddlewareFor, CombinedMiddlewareFor, VariantMiddleware and
+// TransparentMiddleware at various points while handling a request.
+type Hook func(w http.ResponseWriter, r *http.Request, e Event)
+
+// Option configures the middleware returned by MiddlewareFor, CombinedMiddlewareFor, VariantMiddleware
+// and TransparentMiddleware.
+type Option func(*config)
+
+// WithPolicy sets the Policy used when no item satisfies a request.
+func WithPolicy(policy Policy) Option {
+	return func(c *config) {
+		c.policy = policy
+	}
+}
+
+// OnNegotiated sets a hook that is called after an item has been chosen, including by a fallback Policy,
+// just before the next handler is invoked.
+//
+// It is intended for logging, or for setting response headers; it must not write a response body.
+func OnNegotiated(hook Hook) Option {
+	return func(c *config) {
+		c.onNegotiated = hook
+	}
+}
+
+// OnNotAcceptable sets a hook that writes the response when no item satisfies a request
+// and the Policy doesn't provide one, replacing the default 406: Not Acceptable response.
+// A nil hook keeps the default.
+func OnNotAcceptable(hook Hook) Option {
+	return func(c *config) {
+		if hook != nil {
+			c.onNotAcceptable = hook
+		}
+	}
+}
+
+// OnBadRequest sets a hook that writes the response when the header fails to parse or exceeds the limits of the Negotiate,
+// replacing the default 400: Bad Request and 431: Request Header Fields Too Large responses.
+//
+// Limit errors can be identified using errors.Is(e.Err, ErrLimitExceeded).
+// A nil hook keeps the default.
+func OnBadRequest(hook Hook) Option {
+	return func(c *config) {
+		if hook != nil {
+			c.onBadRequest = hook
+		}
+	}
+}
+
+// failures returns the Events for every header that failed, which is just e itself
+// if Failures wasn't set.
+func (e Event) failures() []Event {
+	if len(e.Failures) == 0 {
+		return []Event{e}
+	}
+
+	return e.Failures
+}
+
+// errorStatus returns the status of the response to a request that failed with err.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrLimitExceeded):
+		return http.StatusRequestHeaderFieldsTooLarge
+	case errors.Is(err, ErrNotAcceptable):
+		return http.StatusNotAcceptable
 	default:
-		http.Error(w,
-			"400: Bad Request\nUnable to parse "+h.header+" header.",
-			http.StatusBadRequest)
+		return http.StatusBadRequest
+	}
+}
+
+// severity orders failures by which should decide the status of the response.
+// Problems with the request itself come before the lack of an acceptable item,
+// since the latter may only be a consequence of the former.
+func severity(err error) int {
+	switch errorStatus(err) {
+	case http.StatusRequestHeaderFieldsTooLarge:
+		return 2
+	case http.StatusBadRequest:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// failureText describes the failure of a single header.
+func failureText(e Event) string {
+	switch errorStatus(e.Err) {
+	case http.StatusRequestHeaderFieldsTooLarge:
+		return e.Header + " header exceeds parsing limits."
+	case http.StatusBadRequest:
+		return "Unable to parse " + e.Header + " header."
+	}
+
+	if e.Header == "" {
+		return "Available variants are: " + strings.Join(e.Items, ", ")
+	}
+
+	return "Supported values for " + e.Header + " header are: " + strings.Join(e.Items, ", ")
+}
+
+// writeError is the default OnNotAcceptable and OnBadRequest hook. It writes a plain text error
+// with a line describing each header that failed.
+func writeError(w http.ResponseWriter, r *http.Request, e Event) {
+	status := errorStatus(e.Err)
+	lines := []string{strconv.Itoa(status) + ": " + http.StatusText(status)}
+
+	for _, f := range e.failures() {
+		lines = append(lines, failureText(f))
+	}
+
+	http.Error(w, strings.Join(lines, "\n"), status)
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	headers := make([]string, len(h.axes))
+
+	for i, axis := range h.axes {
+		headers[i] = axis.Header
+	}
+
+	addVary(w.Header(), headers...)
+
+	var negotiated, failures []Event
+
+	for _, axis := range h.axes {
+		query, alternatives, err := axis.Negotiate.rank(FieldValue(r.Header, axis.Header))
+		e := Event{Header: axis.Header, Query: query, Items: axis.Negotiate.items, Err: withHeader(err, axis.Header)}
+
+		switch {
+		case err == nil:
+			e.Result = newResult(axis.Header, alternatives)
+		case errors.Is(err, ErrNotAcceptable) && axis.canFallback:
+			e.Result = axis.fallback
+		default:
+			failures = append(failures, e)
+			continue
+		}
+
+		negotiated = append(negotiated, e)
+	}
+
+	if len(failures) != 0 {
+		e := failures[0]
+
+		for _, f := range failures[1:] {
+			if severity(f.Err) > severity(e.Err) {
+				e = f
+			}
+		}
+
+		e.Failures = failures
+		h.fail(w, r, e)
+		return
+	}
+
+	ctx := r.Context()
+
+	if h.variants {
+		ctx = addVariants(ctx, w.Header(), negotiated)
+	}
+
+	for _, e := range negotiated {
+		if h.onNegotiated != nil {
+			h.onNegotiated(w, r, e)
+		}
+
+		ctx = context.WithValue(ctx, ctxKey(e.Header), e.Result)
+	}
+
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// newHandler returns middleware negotiating on each of axes, configured by options.
+//
+// It panics if an item given by the options fails to parse.
+func newHandler(axes []Axis, options []Option) func(http.Handler) http.Handler {
+	h := handler{axes: make([]axis, len(axes)), config: newConfig(options)}
+
+	for i, a := range axes {
+		a.Header = http.CanonicalHeaderKey(a.Header)
+		h.axes[i].Axis = a
+		h.axes[i].fallback, h.axes[i].canFallback = h.policy.resolve(a.Header, a.Negotiate)
+	}
+
+	return func(next http.Handler) http.Handler {
+		h := h
+		h.next = next
+		return h
 	}
 }
 
@@ -40,17 +274,27 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 //
 // This function will panic if any of the passed items fail to parse.
 //
-// If a matching item is found, the Vary header will be added to the response, and the next handler will be invoked.
-// The matching item can be retrieved using Item(r, header).
+// The header is added to the Vary header of the response, merging it with any existing Vary field lines.
+// If the request contains several field lines for the header, they are combined before negotiating.
+//
+// If a matching item is found, the next handler will be invoked.
+// The matching item can be retrieved using Item(r, header),
+// and the full Result of the negotiation using Negotiated(r, header).
 //
 // A 406: Not Acceptable error will be generated if no items match.
 //
 // A 400: Bad Request error will be generated if any item in the given header fails to parse.
+//
+// A 431: Request Header Fields Too Large error will be generated if the header exceeds DefaultLimits.
 func Middleware(header string, parser ValueParser, items ...string) func(http.Handler) http.Handler {
-	header = http.CanonicalHeaderKey(header)
-	negotiate := Make(parser, items...)
+	return MiddlewareFor(header, Make(parser, items...))
+}
 
-	return func(next http.Handler) http.Handler {
-		return handler{negotiate, header, next}
-	}
+// MiddlewareFor is like Middleware, but negotiates using an existing Negotiate,
+// allowing its limits and strategy to be configured, and accepts options
+// to change the behaviour of the middleware.
+//
+// This function will panic if an item given by the options fails to parse.
+func MiddlewareFor(header string, negotiate Negotiate, options ...Option) func(http.Handler) http.Handler {
+	return newHandler([]Axis{{header, negotiate}}, options)
 }
@@ -0,0 +1,68 @@
+package negotiate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleMiddlewareFor() {
+	middleware := MiddlewareFor("Accept", Make(ParseMedia, "application/json"),
+		OnNegotiated(func(w http.ResponseWriter, r *http.Request, e Event) {
+			fmt.Printf("negotiated %s for %q\n", e.Result.Item, e.Query)
+		}),
+		OnNotAcceptable(func(w http.ResponseWriter, r *http.Request, e Event) {
+			fmt.Printf("nothing in %q satisfies %q\n", e.Items, e.Query)
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		}),
+		OnBadRequest(func(w http.ResponseWriter, r *http.Request, e Event) {
+			var parseError *ParseError
+			if errors.As(e.Err, &parseError) {
+				fmt.Printf("bad %s item %d: %q\n", e.Header, parseError.Index, parseError.Item)
+			}
+			w.WriteHeader(http.StatusBadRequest)
+		}),
+	)
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, accept := range []string{"application/*", "text/html;q=0.5", "text/html, i like waffles"} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", accept)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		fmt.Println(w.Code)
+	}
+
+	// Output:
+	// negotiated application/json for "application/*"
+	// 200
+	// nothing in ["application/json"] satisfies "text/html; q=0.5"
+	// 415
+	// bad Accept item 1: "i like waffles"
+	// 400
+}
+
+func TestMiddlewareFor_NilHooks(t *testing.T) {
+	handler := MiddlewareFor("Accept", Make(ParseMedia, "application/json"),
+		OnNotAcceptable(nil),
+		OnBadRequest(nil))(http.NotFoundHandler())
+
+	for accept, want := range map[string]int{
+		"text/html":     http.StatusNotAcceptable,
+		"text/html;q=x": http.StatusBadRequest,
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != want {
+			t.Errorf("Accept: %s gave status %d, want %d", accept, w.Code, want)
+		}
+	}
+}
@@ -3,16 +3,18 @@
 package negotiate
 
 import (
-	"errors"
+	"net/http"
 	"strings"
 )
 
 // Negotiate holds a list of items that can be negotiated for,
 // and provides a Process() method for selecting one of those items for a given query.
 type Negotiate struct {
-	parser ValueParser
-	items  []string
-	values []Value
+	parser   ValueParser
+	items    []string
+	values   []Value
+	limits   Limits
+	strategy Strategy
 }
 
 // Make returns a Negotiate object for the given items.
@@ -26,7 +28,21 @@ func Make(parser ValueParser, items ...string) Negotiate {
 		values[i] = Must(parser(item))
 	}
 
-	return Negotiate{parser, items, values}
+	return Negotiate{parser: parser, items: items, values: values}
+}
+
+// WithStrategy returns a copy of n that uses the given Strategy to decide between acceptable items,
+// instead of ClientPreference.
+func (n Negotiate) WithStrategy(strategy Strategy) Negotiate {
+	n.strategy = strategy
+	return n
+}
+
+// WithLimits returns a copy of n that parses queries subject to the given limits,
+// instead of DefaultLimits.
+func (n Negotiate) WithLimits(limits Limits) Negotiate {
+	n.limits = limits
+	return n
 }
 
 // String returns all of the items as a comma seperated list.
@@ -34,25 +50,99 @@ func (n Negotiate) String() string {
 	return strings.Join(n.items, ", ")
 }
 
-// ErrNotAcceptable is returned when no item satisfies a query.
-var ErrNotAcceptable = errors.New("no item satisfies query")
-
 // Process returns the item with the highest quality satisfying query,
 // prefering the earlier items given to New in the event of a tie.
 //
-// Returns ErrNotAcceptable if no item satisfies the query.
+// If a Strategy was given using WithStrategy, it decides which item is returned instead.
 //
-// If the value parser returns an error, that error will be returned.
+// Returns a *NotAcceptableError if no item satisfies the query.
+//
+// Returns a *ParseError if the query is malformed or the value parser returns an error.
 func (n Negotiate) Process(query string) (item string, err error) {
-	q, err := ParseQuery(n.parser, query)
+	item, _, err = n.Match(query)
+	return
+}
+
+// ProcessRequest is like Process, using the combined value of every
+// field line of the named header in r as the query.
+//
+// Any error returned will have its Header field set to header.
+func (n Negotiate) ProcessRequest(r *http.Request, header string) (item string, err error) {
+	item, err = n.Process(FieldValue(r.Header, header))
+	return item, withHeader(err, header)
+}
+
+// Match is like Process, but also returns the query value that the item satisfied,
+// which carries the client's quality and any extension parameters it sent.
+func (n Negotiate) Match(query string) (item string, entry QValue, err error) {
+	alternatives, err := n.Rank(query)
+
+	if err != nil {
+		return "", QValue{}, err
+	}
+
+	return alternatives[0].Item, alternatives[0].Match, nil
+}
+
+// Alternative is an item that satisfies a query, as returned by Rank.
+type Alternative struct {
+	Item string
+
+	// Value is the parsed form of Item.
+	Value Value
+
+	// Q is the overall quality of the item: the quality the query gave it,
+	// multiplied by its source quality, and by its score if its Value implements Scorer.
+	Q float64
+
+	// Match is the query value that the item satisfied.
+	Match QValue
+}
+
+// Rank returns every item that satisfies query, from most to least preferred,
+// so that a handler can fall back to the next item if it is unable to produce the first.
+//
+// The first alternative is always the item that Process would return.
+//
+// Returns a *NotAcceptableError if no item satisfies the query.
+//
+// Returns a *ParseError if the query is malformed or the value parser returns an error.
+func (n Negotiate) Rank(query string) ([]Alternative, error) {
+	_, alternatives, err := n.rank(query)
+	return alternatives, err
+}
+
+// rank is like Rank, but also returns the parsed query if it parsed successfully.
+func (n Negotiate) rank(query string) (Query, []Alternative, error) {
+	q, err := ParseQueryLimits(n.parser, query, n.limits)
 
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+
+	candidates := q.Rank(n.values, n.strategy)
+
+	if len(candidates) == 0 {
+		return q, nil, &NotAcceptableError{
+			Query: q,
+			Items: append([]string(nil), n.items...),
+		}
 	}
 
-	if i := q.Choose(n.values); i != -1 {
-		return n.items[i], nil
+	alternatives := make([]Alternative, len(candidates))
+
+	for i, c := range candidates {
+		alternatives[i] = Alternative{n.items[c.Choice], c.Value, c.Q, c.Match}
 	}
 
-	return "", ErrNotAcceptable
+	return q, alternatives, nil
+}
+
+// RankRequest is like Rank, using the combined value of every
+// field line of the named header in r as the query.
+//
+// Any error returned will have its Header field set to header.
+func (n Negotiate) RankRequest(r *http.Request, header string) ([]Alternative, error) {
+	alternatives, err := n.Rank(FieldValue(r.Header, header))
+	return alternatives, withHeader(err, header)
 }
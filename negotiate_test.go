@@ -19,5 +19,31 @@ func ExampleNegotiate() {
 	// "" -> cake
 	// "*, CAKE;q=0.9" -> pie
 	// "pizza" -> error: no item satisfies query
-	// "what is this?" -> error: invalid simple item: "what is this?"
+	// "what is this?" -> error: item 0 at offset 0: invalid simple item: "what is this?"
+}
+
+func ExampleNegotiate_Match() {
+	negotiate := Make(ParseMedia, "application/json", "text/html")
+
+	item, entry, _ := negotiate.Match("text/html;q=0.5, application/json;q=0.9;profile=strict")
+	profile, _ := entry.Extensions.Get("profile")
+
+	fmt.Println(item, entry.Q, profile)
+
+	// Output:
+	// application/json 0.9 strict
+}
+
+func ExampleNegotiate_Rank() {
+	negotiate := Make(ParseLocale, "en", "fr", "de")
+
+	alternatives, _ := negotiate.Rank("fr-CA, fr;q=0.9, en;q=0.5")
+
+	for _, alternative := range alternatives {
+		fmt.Printf("%s q=%g (matched %s)\n", alternative.Item, alternative.Q, alternative.Match)
+	}
+
+	// Output:
+	// fr q=0.9 (matched fr; q=0.9)
+	// en q=0.5 (matched en; q=0.5)
 }
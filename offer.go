@@ -0,0 +1,97 @@
+package negotiate
+
+// SourceQualifier is implemented by values that have a source quality,
+// the server's own preference for a value relative to the others it offers.
+//
+// When choosing between values, Query.Choose multiplies the quality given by the
+// query with the source quality, in the same way as the qs attribute of Apache's type maps.
+type SourceQualifier interface {
+	Value
+
+	// SourceQuality returns a number in the range 0 through 1.
+	SourceQuality() float64
+}
+
+type sourcedValue struct {
+	Value
+	qs float64
+}
+
+func (v sourcedValue) SourceQuality() float64 {
+	return v.qs
+}
+
+// Score passes through the score of the wrapped value, if it has one.
+func (v sourcedValue) Score(ref Value) float64 {
+	return score(v.Value, ref)
+}
+
+// WithSourceQuality returns a copy of v with the given source quality.
+//
+// The returned value satisfies the same values as v, but implements SourceQualifier.
+func WithSourceQuality(v Value, qs float64) Value {
+	if sv, ok := v.(sourcedValue); ok {
+		v = sv.Value
+	}
+
+	return sourcedValue{v, qs}
+}
+
+// sourceQuality returns the source quality of v, or 1 if it doesn't have one.
+func sourceQuality(v Value) float64 {
+	if sq, ok := v.(SourceQualifier); ok {
+		return clampQuality(sq.SourceQuality())
+	}
+
+	return 1
+}
+
+// clampQuality limits q to the range 0 through 1.
+func clampQuality(q float64) float64 {
+	switch {
+	case q > 1:
+		return 1
+	case q > 0:
+		return q
+	default:
+		// Negative numbers, NaN.
+		return 0
+	}
+}
+
+// Offer is an item along with its source quality.
+type Offer struct {
+	Item string
+
+	// QS is the source quality of the item, in the range 0 through 1.
+	// As with WithSourceQuality, a source quality of 0 makes the item unacceptable,
+	// so use NewOffer for an item without a preference.
+	QS float64
+}
+
+// NewOffer returns an Offer for item with a source quality of 1.
+func NewOffer(item string) Offer {
+	return Offer{Item: item, QS: 1}
+}
+
+// MakeOffers is like Make, but each item is given a source quality.
+//
+// For example, a server that can produce both SVG and PNG, but considers the PNG to be
+// lossy, might offer Offer{"image/svg+xml", 1} and Offer{"image/png", 0.6}.
+// A client sending "image/png, image/svg+xml;q=0.8" would then be sent the SVG,
+// as its overall quality of 0.8 × 1 beats the PNG's 1 × 0.6.
+func MakeOffers(parser ValueParser, offers ...Offer) Negotiate {
+	items := make([]string, len(offers))
+
+	for i, offer := range offers {
+		items[i] = offer.Item
+	}
+
+	n := Make(parser, items...)
+
+	for i, offer := range offers {
+		n.values[i] = WithSourceQuality(n.values[i], offer.QS)
+	}
+
+	return n
+}
@@ -0,0 +1,37 @@
+package negotiate
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleMakeOffers() {
+	negotiate := MakeOffers(ParseMedia,
+		Offer{"image/svg+xml", 1},
+		Offer{"image/png", 0.6},
+		Offer{"image/gif", 0})
+
+	for _, query := range []string{"", "image/png, image/svg+xml;q=0.8", "image/png, image/svg+xml;q=0.5", "image/gif"} {
+		if item, err := negotiate.Process(query); err != nil {
+			fmt.Printf("%q -> error: %v\n", query, err)
+		} else {
+			fmt.Printf("%q -> %s\n", query, item)
+		}
+	}
+
+	// Output:
+	// "" -> image/svg+xml
+	// "image/png, image/svg+xml;q=0.8" -> image/svg+xml
+	// "image/png, image/svg+xml;q=0.5" -> image/png
+	// "image/gif" -> error: no item satisfies query
+}
+
+func TestNewOffer(t *testing.T) {
+	negotiate := MakeOffers(ParseSimple, NewOffer("gzip"), Offer{Item: "br", QS: 0.5}, Offer{Item: "deflate"})
+
+	alternatives, err := negotiate.Rank("br, gzip, deflate")
+
+	if err != nil || len(alternatives) != 2 || alternatives[0].Item != "gzip" || alternatives[0].Q != 1 {
+		t.Errorf("Rank() = %v, %v", alternatives, err)
+	}
+}
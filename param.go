@@ -0,0 +1,78 @@
+package negotiate
+
+import (
+	"strings"
+)
+
+// Param is a single name/value parameter, such as an accept-ext.
+//
+// The Value of a parameter given as a bare name with no "=" is empty.
+type Param struct {
+	Name, Value string
+}
+
+func (p Param) String() string {
+	if p.Value == "" {
+		return p.Name
+	}
+
+	return p.Name + "=" + quote(p.Value)
+}
+
+// Params is an ordered list of parameters, in the order they were given.
+type Params []Param
+
+// Get returns the value of the first parameter with the given name,
+// compared case insensitively, and reports whether it was present.
+func (ps Params) Get(name string) (value string, ok bool) {
+	for _, p := range ps {
+		if strings.EqualFold(p.Name, name) {
+			return p.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func (ps Params) String() string {
+	var b strings.Builder
+
+	for i, p := range ps {
+		if i != 0 {
+			b.WriteString("; ")
+		}
+
+		b.WriteString(p.String())
+	}
+
+	return b.String()
+}
+
+// quote returns s unchanged if it is a token, or as a quoted-string otherwise.
+func quote(s string) string {
+	token := s != ""
+
+	for i := 0; token && i < len(s); i++ {
+		token = isTchar(s[i])
+	}
+
+	if token {
+		return s
+	}
+
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+
+		b.WriteByte(s[i])
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
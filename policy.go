@@ -0,0 +1,96 @@
+package negotiate
+
+import (
+	"fmt"
+)
+
+// Policy decides what Middleware does when no item satisfies a request,
+// and what VariantMiddleware and TransparentMiddleware do when no variant is acceptable.
+//
+// RFC 9110 allows a server to ignore the header and send a default representation
+// instead of a 406: Not Acceptable, which some older clients and crawlers rely on.
+type Policy struct {
+	kind policyKind
+	item string
+}
+
+type policyKind int
+
+const (
+	policyStrict policyKind = iota
+	policyFallbackFirst
+	policyFallbackItem
+	policyPassThrough
+)
+
+var (
+	// Strict generates a 406: Not Acceptable error. It is the default Policy.
+	Strict = Policy{kind: policyStrict}
+
+	// FallbackFirst invokes the next handler with the first item given to Make, as if it had been chosen.
+	// For a VariantSet, it is the first variant given to MakeVariants.
+	FallbackFirst = Policy{kind: policyFallbackFirst}
+
+	// PassThrough invokes the next handler without an item, so Item(r, header) returns an empty string.
+	// For a VariantSet, ChosenVariant(r) reports that there was no variant.
+	PassThrough = Policy{kind: policyPassThrough}
+)
+
+// Fallback returns a Policy that invokes the next handler with the given item, as if it had been chosen.
+//
+// The item need not be one of the items given to Make, but must be accepted by its parser.
+// For a VariantSet, it must be the name of one of the variants.
+func Fallback(item string) Policy {
+	return Policy{kind: policyFallbackItem, item: item}
+}
+
+// resolve returns the Result to use when nothing satisfies a request for header,
+// and reports whether the next handler should be invoked with it.
+//
+// It panics if the policy's item fails to parse.
+func (p Policy) resolve(header string, n Negotiate) (Result, bool) {
+	result := Result{Header: header, Fallback: true}
+
+	switch p.kind {
+	case policyFallbackFirst:
+		if len(n.items) == 0 {
+			return result, true
+		}
+
+		result.Item, result.Value = n.items[0], n.values[0]
+	case policyFallbackItem:
+		result.Item, result.Value = p.item, Must(n.parser(p.item))
+	case policyPassThrough:
+	default:
+		return Result{}, false
+	}
+
+	return result, true
+}
+
+// resolveVariant returns the variant to use when no variant in s is acceptable, and whether there is one,
+// and reports whether the next handler should be invoked.
+//
+// It panics if the policy's item isn't the name of a variant.
+func (p Policy) resolveVariant(s VariantSet) (variant Variant, chosen, ok bool) {
+	switch p.kind {
+	case policyFallbackFirst:
+		if len(s.variants) == 0 {
+			return Variant{}, false, true
+		}
+
+		return s.variants[0], true, true
+	case policyFallbackItem:
+		for _, v := range s.variants {
+			if v.Name == p.item {
+				return v, true, true
+			}
+		}
+
+		panic(fmt.Errorf("no variant is named %q", p.item))
+	case policyPassThrough:
+		return Variant{}, false, true
+	default:
+		return Variant{}, false, false
+	}
+}
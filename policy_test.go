@@ -0,0 +1,46 @@
+package negotiate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+)
+
+func ExamplePolicy() {
+	policies := []struct {
+		name   string
+		policy Policy
+	}{
+		{"Strict", Strict},
+		{"FallbackFirst", FallbackFirst},
+		{"Fallback", Fallback("fr")},
+		{"PassThrough", PassThrough},
+	}
+
+	for _, p := range policies {
+		middleware := MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "de"), WithPolicy(p.policy))
+
+		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, _ := Negotiated(r, "Accept-Language")
+			fmt.Fprintf(w, "Negotiated language is %q, fallback=%t\n", result.Item, result.Fallback)
+		}))
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Language", "ja")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		fmt.Printf("%s: ", p.name)
+		io.Copy(os.Stdout, w.Result().Body)
+	}
+
+	// Output:
+	// Strict: 406: Not Acceptable
+	// Supported values for Accept-Language header are: en, de
+	// FallbackFirst: Negotiated language is "en", fallback=true
+	// Fallback: Negotiated language is "fr", fallback=true
+	// PassThrough: Negotiated language is "", fallback=true
+}
@@ -0,0 +1,178 @@
+package negotiate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// Problem is an RFC 9457 problem details object, describing why negotiation failed.
+type Problem struct {
+	// Type is a URI identifying the type of problem. If empty, it is taken to be "about:blank".
+	Type string `json:"type,omitempty" xml:"type,omitempty"`
+
+	Title  string `json:"title" xml:"title"`
+	Status int    `json:"status" xml:"status"`
+	Detail string `json:"detail,omitempty" xml:"detail,omitempty"`
+
+	// Header is the name of the header that negotiation failed for.
+	Header string `json:"header" xml:"header"`
+
+	// Supported holds the values supported for the header.
+	Supported []string `json:"supported,omitempty" xml:"-"`
+
+	// ParseError describes why the header failed to parse, if it did.
+	ParseError *ProblemParseError `json:"parse-error,omitempty" xml:"parse-error,omitempty"`
+}
+
+// MarshalXML encodes p following appendix B of RFC 9457, which represents arrays as a sequence of i elements.
+func (p Problem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type problem Problem
+
+	type list struct {
+		I []string `xml:"i"`
+	}
+
+	v := struct {
+		problem
+		Supported *list `xml:"supported,omitempty"`
+	}{problem: problem(p)}
+
+	if len(p.Supported) != 0 {
+		v.Supported = &list{p.Supported}
+	}
+
+	start.Name = xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}
+
+	return e.EncodeElement(v, start)
+}
+
+// ProblemParseError is the machine-readable form of a ParseError, for use in a Problem.
+type ProblemParseError struct {
+	Item    string `json:"item" xml:"item"`
+	Index   int    `json:"index" xml:"index"`
+	Offset  int    `json:"offset" xml:"offset"`
+	Message string `json:"message" xml:"message"`
+}
+
+// NewProblem returns the Problem describing an Event passed to an OnNotAcceptable or OnBadRequest hook.
+//
+// When several headers failed, its Detail describes each of them on a separate line,
+// and the other fields describe the failure that decided the status.
+func NewProblem(e Event) Problem {
+	p := Problem{Header: e.Header, Status: errorStatus(e.Err)}
+	p.Title = http.StatusText(p.Status)
+
+	details := make([]string, 0, len(e.Failures))
+
+	for _, f := range e.failures() {
+		details = append(details, problemDetail(f))
+	}
+
+	p.Detail = strings.Join(details, "\n")
+
+	var parseError *ParseError
+
+	switch {
+	case p.Status == http.StatusNotAcceptable:
+		p.Supported = e.Items
+	case p.Status == http.StatusBadRequest && errors.As(e.Err, &parseError):
+		p.ParseError = &ProblemParseError{parseError.Item, parseError.Index, parseError.Offset, parseError.Err.Error()}
+	}
+
+	return p
+}
+
+// problemDetail describes the failure of a single header, giving the limit it exceeded, if any.
+func problemDetail(e Event) string {
+	var limitError *LimitError
+
+	if errors.As(e.Err, &limitError) {
+		return fmt.Sprintf("The %s header exceeds the %s limit of %d.", e.Header, limitError.Limit, limitError.Max)
+	}
+
+	return failureText(e)
+}
+
+// problemFormats are the formats a Problem can be written in, most preferred first.
+var problemFormats = Make(ParseMedia, "application/problem+json", "application/problem+xml", "text/html", "text/plain")
+
+var problemTemplate = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Status}}: {{.Title}}</title></head>
+<body>
+<h1>{{.Status}}: {{.Title}}</h1>
+<p>{{.Detail}}</p>
+{{- with .Supported}}
+<ul>
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with .ParseError}}
+<p>Item {{.Index}} ({{.Item}}) at offset {{.Offset}}: {{.Message}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// writeHeaderFor writes the response header with the given status, and a Content-Type of whichever of formats
+// best satisfies the Accept header of r, which it returns.
+// If none of them do, or the Accept header can't be parsed, the first of formats is used regardless.
+//
+// Since the body is generated from the request, X-Content-Type-Options is set to nosniff.
+func writeHeaderFor(w http.ResponseWriter, r *http.Request, formats Negotiate, status int) string {
+	addVary(w.Header(), "Accept")
+
+	format, err := formats.ProcessRequest(r, "Accept")
+
+	if err != nil {
+		format = formats.items[0]
+	}
+
+	w.Header().Set("Content-Type", format+"; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	return format
+}
+
+// WriteProblem writes p as the response, in whichever of application/problem+json, application/problem+xml,
+// text/html or text/plain best satisfies the Accept header of r.
+//
+// If none of them do, or the Accept header can't be parsed, application/problem+json is used regardless.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem) {
+	format := writeHeaderFor(w, r, problemFormats, p.Status)
+
+	switch format {
+	case "application/problem+json":
+		json.NewEncoder(w).Encode(p)
+	case "application/problem+xml":
+		fmt.Fprint(w, xml.Header)
+		xml.NewEncoder(w).Encode(p)
+		fmt.Fprintln(w)
+	case "text/html":
+		problemTemplate.Execute(w, p)
+	default:
+		fmt.Fprintf(w, "%d: %s\n%s\n", p.Status, p.Title, p.Detail)
+	}
+}
+
+// ProblemHook is a Hook for OnNotAcceptable and OnBadRequest that responds with
+// the Problem describing the Event, written using WriteProblem.
+func ProblemHook(w http.ResponseWriter, r *http.Request, e Event) {
+	WriteProblem(w, r, NewProblem(e))
+}
+
+// WithProblemDetails is an Option that sets ProblemHook as both the OnNotAcceptable and OnBadRequest hooks.
+func WithProblemDetails() Option {
+	return func(c *config) {
+		c.onNotAcceptable = ProblemHook
+		c.onBadRequest = ProblemHook
+	}
+}
@@ -0,0 +1,66 @@
+package negotiate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func ExampleWithProblemDetails() {
+	middleware := MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr"), WithProblemDetails())
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	requests := []struct {
+		accept, language string
+	}{
+		{"application/json, application/problem+json", "de"},
+		{"application/problem+xml", "en;q=0.5, i like waffles."},
+		{"text/plain", "de"},
+	}
+
+	for _, req := range requests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", req.accept)
+		r.Header.Set("Accept-Language", req.language)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		fmt.Println(w.Code, w.Header().Get("Content-Type"))
+		io.Copy(os.Stdout, w.Result().Body)
+		fmt.Println()
+	}
+
+	// Output:
+	// 406 application/problem+json; charset=utf-8
+	// {"title":"Not Acceptable","status":406,"detail":"Supported values for Accept-Language header are: en, fr","header":"Accept-Language","supported":["en","fr"]}
+	//
+	// 400 application/problem+xml; charset=utf-8
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <problem xmlns="urn:ietf:rfc:7807"><title>Bad Request</title><status>400</status><detail>Unable to parse Accept-Language header.</detail><header>Accept-Language</header><parse-error><item>i like waffles.</item><index>1</index><offset>10</offset><message>bad locale: &#34;i like waffles.&#34;</message></parse-error></problem>
+	//
+	// 406 text/plain; charset=utf-8
+	// 406: Not Acceptable
+	// Supported values for Accept-Language header are: en, fr
+}
+
+func TestWriteProblem_html(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "text/html")
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, r, NewProblem(Event{Header: "Accept-Encoding", Items: []string{"gzip", "<br>"}, Err: ErrNotAcceptable}))
+
+	if body := w.Body.String(); !strings.Contains(body, "<li>gzip</li>") || !strings.Contains(body, "<li>&lt;br&gt;</li>") {
+		t.Errorf("WriteProblem() body = %q", body)
+	}
+
+	if got := w.Header().Get("Vary"); got != "Accept" {
+		t.Errorf("Vary = %q, want %q", got, "Accept")
+	}
+}
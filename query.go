@@ -2,9 +2,7 @@ package negotiate
 
 import (
 	"fmt"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 )
 
@@ -12,6 +10,13 @@ import (
 type QValue struct {
 	Value
 	Q float64
+
+	// Extensions holds any accept-ext parameters that followed the weight, in order.
+	Extensions Params
+
+	// Index is the position of the value in the query as it was sent, counting from 0.
+	// It is unaffected by sorting the query.
+	Index int
 }
 
 func (v QValue) String() string {
@@ -20,7 +25,12 @@ func (v QValue) String() string {
 	switch true {
 	case q >= 1:
 		// If no "q" parameter is present, the default weight is 1.
-		return v.Value.String()
+		if len(v.Extensions) == 0 {
+			return v.Value.String()
+		}
+
+		// Extensions can only follow an explicit weight.
+		q = 1
 	case q > 0:
 		// In range, do nothing.
 	default:
@@ -29,6 +39,10 @@ func (v QValue) String() string {
 	}
 
 	// MUST NOT generate more than three digits after the decimal point.
+	if len(v.Extensions) != 0 {
+		return fmt.Sprintf("%s; q=%.3g; %s", v.Value.String(), q, v.Extensions)
+	}
+
 	return fmt.Sprintf("%s; q=%.3g", v.Value.String(), q)
 }
 
@@ -42,12 +56,14 @@ func (q Query) Len() int {
 // Less enables you to sort the items in a query by precedence using sort.Stable(q).
 //
 // It returns returns true if the q[i] has a higher precedence than q[j].
+// More specific values have a higher precedence, as required by RFC 9110, section 12.5.1.
+// Between values that are equally specific, the one with the higher quality has the higher precedence.
 func (q Query) Less(i, j int) bool {
 	if a, b := q[i].Specificity(), q[j].Specificity(); a != b {
 		return b < a
 	}
 
-	return q[i].Q > q[i].Q
+	return q[i].Q > q[j].Q
 }
 
 func (q Query) Swap(i, j int) {
@@ -70,76 +86,162 @@ func (q Query) String() string {
 
 // Find finds the first value in the query that is satisfied by the given value.
 //
+// For a query sorted by precedence, as returned by ParseQuery, this is the most
+// specific value that applies, which is the one that decides the quality of v.
+//
 // If that value has a positive quality, its index is returned,
 // otherwise -1 is returned.
 //
 // If no value in the query is satisfied by the value, -1 is returned.
+//
+// Of the equally specific values that v satisfies, the narrowest applies, so that "es-MX"
+// applies before "es-419" (Latin America) whichever has the higher precedence.
+//
+// If v implements Scorer and fully satisfies no value in the query, the values it partly satisfies
+// are considered instead, and the index of the one giving the highest quality multiplied by the score is returned.
 func (q Query) Find(v Value) int {
+	i, _ := q.find(v)
+	return i
+}
+
+// find is like Find, but also returns the score of v against the value found.
+func (q Query) find(v Value) (int, float64) {
+	best, bestScore := -1, 0.0
+
 	for i, qv := range q {
-		if v.Satisfies(qv.Value) {
-			if qv.Q > 0 {
-				return i
+		switch s := score(v, qv.Value); {
+		case s >= 1:
+			// The most specific value that v fully satisfies decides its quality,
+			// and a value of 0 means "not acceptable".
+			if i = q.narrowest(v, i); q[i].Q > 0 {
+				return i, 1
 			}
 
-			// a value of 0 means "not acceptable"
-			break
+			return -1, 0
+		case s > 0 && qv.Q > 0 && (best == -1 || qv.Q*s > q[best].Q*bestScore):
+			// A partial match only counts if nothing is fully satisfied,
+			// and can't make a value unacceptable.
+			best, bestScore = i, s
 		}
 	}
 
-	return -1
+	return best, bestScore
+}
+
+// narrowest returns the index of the narrowest of the values that v fully satisfies and that are as specific as q[i],
+// starting from q[i]. One value is narrower than another if it fully satisfies it, but not the other way around,
+// as "es-MX" is narrower than "es-419" (Latin America), although both are equally specific.
+func (q Query) narrowest(v Value, i int) int {
+	for j := i + 1; j < len(q) && q[j].Specificity() == q[i].Specificity(); j++ {
+		if score(v, q[j].Value) >= 1 && score(q[j].Value, q[i].Value) >= 1 && score(q[i].Value, q[j].Value) < 1 {
+			i = j
+		}
+	}
+
+	return i
 }
 
 // Choose returns the index of the best value in the given list of choices,
 // or -1 if none of the choices satisfy the query.
 //
-// "best" is the choice that yields the highest quality value.
+// "best" is the choice that yields the highest quality value,
+// which is the quality the query gives it multiplied by its source quality (see WithSourceQuality),
+// and by its score if it implements Scorer.
 // In the case of a tie, the query item with the higher precedence is used.
 // If that query item can be satisfied by more than once choice, the one
 // that appears first in the choices list is used.
+//
+// Use Rank to choose using a different Strategy.
 func (q Query) Choose(choices []Value) int {
-	var (
-		bestChoiceIndex = -1
-		bestQueryIndex  int
-	)
+	choice, _ := q.Match(choices)
+	return choice
+}
 
-	for choiceIndex, cv := range choices {
-		if queryIndex := q.Find(cv); queryIndex != -1 {
-			if bestChoiceIndex == -1 || q[queryIndex].Q > q[bestQueryIndex].Q || (q[queryIndex].Q == q[bestQueryIndex].Q && queryIndex < bestQueryIndex) {
-				bestChoiceIndex, bestQueryIndex = choiceIndex, queryIndex
-			}
-		}
+// Match is like Choose, but also returns the index of the query value that the
+// chosen value satisfied, so that its quality and extensions can be inspected.
+//
+// Both indexes are -1 if none of the choices satisfy the query.
+func (q Query) Match(choices []Value) (choice, entry int) {
+	if candidates := q.Rank(choices, nil); len(candidates) != 0 {
+		return candidates[0].Choice, candidates[0].Entry
 	}
 
-	return bestChoiceIndex
+	return -1, -1
 }
 
+// Rank returns a Candidate for each of the choices that satisfy the query with a positive overall quality,
+// ordered from most to least preferred according to strategy.
 //
-var reItem = regexp.MustCompile(`^([^;]+?(?:;(?:[^;"]|"(?:[^"\\]|\\.)*")*)*?)(?:;\s*[qQ]=(1(?:\.0{0,3})?|0(?:\.[0-9]{0,3})?)\s*(?:;.*)?)?$`)
+// If strategy is nil, ClientPreference is used.
+func (q Query) Rank(choices []Value, strategy Strategy) []Candidate {
+	var candidates []Candidate
+
+	for choiceIndex, cv := range choices {
+		queryIndex, s := q.find(cv)
+
+		if queryIndex == -1 {
+			continue
+		}
+
+		// A source quality of 0 makes a choice unacceptable.
+		quality := q[queryIndex].Q * s * sourceQuality(cv)
+
+		if quality <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, Candidate{choiceIndex, cv, queryIndex, q[queryIndex], quality})
+	}
+
+	if strategy == nil {
+		strategy = ClientPreference
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return strategy(candidates[i], candidates[j])
+	})
+
+	return candidates
+}
 
 // ParseQuery parses a query, and returns the query with its values sorted by precedence.
 //
-// Extension parameters are not supported, and will be silently discarded if present.
+// The query is tokenized following the list, parameter and quoted-string rules of RFC 9110,
+// so a comma or semicolon inside a quoted parameter value does not split the value.
+// Each value, along with any of its parameters that precede the weight, is then passed to parser.
+//
+// Any extension parameters following a weight are kept, in order, in the Extensions of its QValue.
+//
+// Errors are returned as a *ParseError.
+//
+// The query is subject to DefaultLimits.
 //
 // An empty query will be satisfied by anything.
 func ParseQuery(parser ValueParser, query string) (q Query, err error) {
-	if strings.TrimSpace(query) == "" {
-		query = "*"
+	return ParseQueryLimits(parser, query, DefaultLimits)
+}
+
+// ParseQueryLimits is like ParseQuery, but checks the query against the given limits.
+//
+// If a limit is exceeded, the returned *ParseError will wrap a *LimitError.
+func ParseQueryLimits(parser ValueParser, query string, limits Limits) (q Query, err error) {
+	elements, err := parseList(query, limits.resolve())
+
+	if err != nil {
+		return nil, err
 	}
 
-	items := strings.Split(query, ",")
-	q = make(Query, len(items))
+	if len(elements) == 0 {
+		elements = []element{{value: "*", q: 1}}
+	}
 
-	for i, item := range items {
-		match := reItem.FindStringSubmatch(item)
+	q = make(Query, len(elements))
 
-		q[i].Q = 1.0
-		q[i].Value, err = parser(strings.TrimSpace(match[1]))
+	for i, e := range elements {
+		q[i].Q, q[i].Extensions, q[i].Index = e.q, e.ext, i
+		q[i].Value, err = parser(e.value)
 		if err != nil {
-			return nil, err
-		}
-
-		if value, err := strconv.ParseFloat(match[2], 10); err == nil {
-			q[i].Q = value
+			return nil, &ParseError{Item: elementText(query, e.offset), Index: i, Offset: e.offset, Err: err}
 		}
 	}
 
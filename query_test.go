@@ -3,6 +3,7 @@ package negotiate
 import (
 	"fmt"
 	"math"
+	"sort"
 	"testing"
 )
 
@@ -41,22 +42,22 @@ func ExampleQuery() {
 
 func TestQValue_String(t *testing.T) {
 	tests := []struct {
-		name   string
+		name  string
 		value QValue
-		want   string
+		want  string
 	}{
 		// These have legal q values.
-		{"q = 1", QValue{simpleValue("test"), 1}, "test"},
-		{"q = 0", QValue{simpleValue("test"), 0}, "test; q=0"},
-		{"q = 0.5", QValue{simpleValue("test"), 0.5}, "test; q=0.5"},
+		{"q = 1", QValue{Value: simpleValue("test"), Q: 1}, "test"},
+		{"q = 0", QValue{Value: simpleValue("test"), Q: 0}, "test; q=0"},
+		{"q = 0.5", QValue{Value: simpleValue("test"), Q: 0.5}, "test; q=0.5"},
 
 		// These have illegal q values.
-		{"q = 0.12345", QValue{simpleValue("test"), 0.12345}, "test; q=0.123"},
-		{"q > 1", QValue{simpleValue("test"), 2}, "test"},
-		{"q < 0", QValue{simpleValue("test"), -1}, "test; q=0"},
-		{"q = +Inf", QValue{simpleValue("test"), math.Inf(1)}, "test"},
-		{"q = -Inf", QValue{simpleValue("test"), math.Inf(-1)}, "test; q=0"},
-		{"q = NaN", QValue{simpleValue("test"), math.NaN()}, "test; q=0"},
+		{"q = 0.12345", QValue{Value: simpleValue("test"), Q: 0.12345}, "test; q=0.123"},
+		{"q > 1", QValue{Value: simpleValue("test"), Q: 2}, "test"},
+		{"q < 0", QValue{Value: simpleValue("test"), Q: -1}, "test; q=0"},
+		{"q = +Inf", QValue{Value: simpleValue("test"), Q: math.Inf(1)}, "test"},
+		{"q = -Inf", QValue{Value: simpleValue("test"), Q: math.Inf(-1)}, "test; q=0"},
+		{"q = NaN", QValue{Value: simpleValue("test"), Q: math.NaN()}, "test; q=0"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -66,3 +67,143 @@ func TestQValue_String(t *testing.T) {
 		})
 	}
 }
+
+func TestParseQuery_quotedComma(t *testing.T) {
+	q, err := ParseQuery(ParseMedia, `text/plain; foo="a,b", text/html;q=0.5`)
+
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+
+	if got, want := q.String(), `text/plain; foo="a,b", text/html; q=0.5`; got != want {
+		t.Errorf("ParseQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestQValue_StringExtensions(t *testing.T) {
+	tests := []struct {
+		value QValue
+		want  string
+	}{
+		{QValue{Value: simpleValue("a"), Q: 1, Extensions: Params{{"x", ""}}}, "a; q=1; x"},
+		{QValue{Value: simpleValue("a"), Q: 0.5, Extensions: Params{{"x", "y z"}, {"w", "v"}}}, `a; q=0.5; x="y z"; w=v`},
+	}
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("QValue.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+// qualityOf returns the quality the query assigns to v, or 0 if v is not acceptable.
+func qualityOf(q Query, v Value) float64 {
+	if i := q.Find(v); i != -1 {
+		return q[i].Q
+	}
+
+	return 0
+}
+
+// TestQuery_RFC9110 checks the examples given in RFC 9110, section 12.5.
+func TestQuery_RFC9110(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser ValueParser
+		query  string
+		want   map[string]float64
+	}{
+		{"12.5.1 precedence", ParseMedia, "text/*, text/plain, text/plain;format=flowed, */*", map[string]float64{
+			"text/plain;format=flowed": 1,
+			"text/plain":               1,
+			"text/html":                1,
+			"image/png":                1,
+		}},
+		{"12.5.1 quality", ParseMedia, "text/*;q=0.3, text/plain;q=0.7, text/plain;format=flowed, text/plain;format=fixed;q=0.4, */*;q=0.5", map[string]float64{
+			"text/plain;format=flowed": 1,
+			"text/plain":               0.7,
+			"text/html":                0.3,
+			"image/jpeg":               0.5,
+			"text/plain;format=fixed":  0.4,
+		}},
+		{"12.5.1 audio", ParseMedia, "audio/*; q=0.2, audio/basic", map[string]float64{
+			"audio/basic": 1,
+			"audio/mpeg":  0.2,
+			"video/mp4":   0,
+		}},
+		{"12.5.1 parameter count", ParseMedia, "text/html;level=1;q=0.2, text/html;charset=utf-8;level=1;q=0.9", map[string]float64{
+			"text/html;level=1":               0.2,
+			"text/html;level=1;charset=utf-8": 0.9,
+		}},
+		{"12.5.2", ParseSimple, "iso-8859-5, unicode-1-1;q=0.8", map[string]float64{
+			"iso-8859-5":  1,
+			"unicode-1-1": 0.8,
+			"utf-8":       0,
+		}},
+		{"12.5.3 list", ParseSimple, "compress, gzip", map[string]float64{
+			"compress": 1,
+			"gzip":     1,
+			"br":       0,
+		}},
+		{"12.5.3 wildcard", ParseSimple, "*", map[string]float64{
+			"gzip": 1,
+		}},
+		{"12.5.3 weighted", ParseSimple, "compress;q=0.5, gzip;q=1.0", map[string]float64{
+			"compress": 0.5,
+			"gzip":     1,
+		}},
+		{"12.5.3 exclusion", ParseSimple, "gzip;q=1.0, identity; q=0.5, *;q=0", map[string]float64{
+			"gzip":     1,
+			"identity": 0.5,
+			"compress": 0,
+		}},
+		{"12.5.4", ParseLocale, "da, en-gb;q=0.8, en;q=0.7", map[string]float64{
+			"da":    1,
+			"en-GB": 0.8,
+			"en":    0.7,
+			"en-US": 0.7,
+			"fr":    0,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.parser, tt.query)
+
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error = %v", tt.query, err)
+			}
+
+			// The result must not depend on the order the client listed its values in.
+			reversed := make(Query, len(q))
+			for i, v := range q {
+				reversed[len(q)-1-i] = v
+			}
+			sort.Stable(reversed)
+
+			for item, want := range tt.want {
+				v := Must(tt.parser(item))
+
+				if got := qualityOf(q, v); got != want {
+					t.Errorf("quality of %s = %g, want %g", item, got, want)
+				}
+
+				if got := qualityOf(reversed, v); got != want {
+					t.Errorf("quality of %s in reversed query = %g, want %g", item, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestQuery_Less(t *testing.T) {
+	q := Query{
+		{Value: simpleValue("*"), Q: 1},
+		{Value: simpleValue("a"), Q: 0.5},
+		{Value: simpleValue("b"), Q: 0.8},
+	}
+
+	sort.Stable(q)
+
+	if got, want := q.String(), "b; q=0.8, a; q=0.5, *"; got != want {
+		t.Errorf("sorted query = %q, want %q", got, want)
+	}
+}
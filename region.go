@@ -0,0 +1,85 @@
+package negotiate
+
+import (
+	"strings"
+)
+
+// regionContainment lists the regions directly contained by each UN M.49 region,
+// following the territory containment data of CLDR, in which Latin America (419)
+// sits between the Americas (019) and its subregions.
+var regionContainment = map[string]string{
+	"001": "002 009 019 142 150",
+
+	// Africa.
+	"002": "015 202",
+	"202": "011 014 017 018",
+	"011": "BF BJ CI CV GH GM GN GW LR ML MR NE NG SH SL SN TG",
+	"014": "BI DJ ER ET IO KE KM MG MU MW MZ RE RW SC SO SS TF TZ UG YT ZM ZW",
+	"015": "DZ EA EG EH IC LY MA SD TN",
+	"017": "AO CD CF CG CM GA GQ ST TD",
+	"018": "BW LS NA SZ ZA",
+
+	// The Americas.
+	"019": "021 419",
+	"021": "BM CA GL PM US",
+	"419": "005 013 029",
+	"005": "AR BO BR BV CL CO EC FK GF GS GY PE PY SR UY VE",
+	"013": "BZ CR GT HN MX NI PA SV",
+	"029": "AG AI AW BB BL BQ BS CU CW DM DO GD GP HT JM KN KY LC MF MQ MS PR SX TC TT VC VG VI",
+
+	// Asia.
+	"142": "030 034 035 143 145",
+	"030": "CN HK JP KP KR MN MO TW",
+	"034": "AF BD BT IN IR LK MV NP PK",
+	"035": "BN ID KH LA MM MY PH SG TH TL VN",
+	"143": "KG KZ TJ TM UZ",
+	"145": "AE AM AZ BH CY GE IL IQ JO KW LB OM PS QA SA SY TR YE",
+
+	// Europe.
+	"150": "039 151 154 155",
+	"039": "AD AL BA ES GI GR HR IT ME MK MT PT RS SI SM VA XK",
+	"151": "BG BY CZ HU MD PL RO RU SK UA",
+	"154": "AX DK EE FI FO GB GG IE IM IS JE LT LV NO SE SJ",
+	"155": "AT BE CH DE FR LI LU MC NL",
+
+	// Oceania.
+	"009": "053 054 057 061",
+	"053": "AU NF NZ",
+	"054": "FJ NC PG SB VU",
+	"057": "FM GU KI MH MP NR PW UM",
+	"061": "AS CK NU PF PN TK TO TV WF WS",
+}
+
+// regionParents maps each region to the region that directly contains it.
+var regionParents = map[string]string{}
+
+func init() {
+	for parent, children := range regionContainment {
+		for _, child := range strings.Fields(children) {
+			regionParents[child] = parent
+		}
+	}
+}
+
+// regionContains reports whether the region outer is, or contains, the region inner.
+func regionContains(outer, inner string) bool {
+	for r := inner; r != ""; r = regionParents[r] {
+		if r == outer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// regionsRelated reports whether a and b are both contained by a region smaller than the world,
+// such as Mexico and Argentina, which are both in Latin America.
+func regionsRelated(a, b string) bool {
+	for r := regionParents[a]; r != "" && r != "001"; r = regionParents[r] {
+		if regionContains(r, b) {
+			return true
+		}
+	}
+
+	return false
+}
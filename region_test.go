@@ -0,0 +1,55 @@
+package negotiate
+
+import (
+	"testing"
+)
+
+func TestRegionContains(t *testing.T) {
+	tests := []struct {
+		outer, inner string
+		want         bool
+	}{
+		{"419", "MX", true},
+		{"419", "AR", true},
+		{"419", "US", false},
+		{"019", "US", true},
+		{"150", "FR", true},
+		{"001", "JP", true},
+		{"001", "419", true},
+		{"FR", "FR", true},
+		{"MX", "419", false},
+		{"150", "ZZ", false},
+	}
+	for _, tt := range tests {
+		if got := regionContains(tt.outer, tt.inner); got != tt.want {
+			t.Errorf("regionContains(%q, %q) = %v, want %v", tt.outer, tt.inner, got, tt.want)
+		}
+	}
+
+	// Every region is reachable from the world.
+	for region := range regionParents {
+		if !regionContains("001", region) {
+			t.Errorf("%s is not contained by 001", region)
+		}
+	}
+}
+
+func TestRegionsRelated(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"MX", "AR", true},
+		{"RS", "ME", true},
+		{"US", "CA", true},
+		{"US", "MX", true},
+		{"ES", "MX", false},
+		{"GB", "AU", false},
+		{"ZZ", "GB", false},
+	}
+	for _, tt := range tests {
+		if got := regionsRelated(tt.a, tt.b); got != tt.want {
+			t.Errorf("regionsRelated(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package negotiate
+
+import (
+	"net/http"
+)
+
+// Result describes the outcome of negotiating on a header, as stored in the request context by Middleware.
+type Result struct {
+	// Header is the canonical name of the header that was negotiated on.
+	Header string
+
+	// Item is the chosen item, exactly as it was given to Make.
+	Item string
+
+	// Value is the parsed form of Item.
+	Value Value
+
+	// Match is the query value that Item satisfied.
+	//
+	// This is the value the client asked for, so it carries the client's quality, any
+	// parameters it gave (such as the indent in "application/json; indent=2"),
+	// and any extension parameters following its weight.
+	Match QValue
+
+	// Q is the overall quality of Item.
+	Q float64
+
+	// Alternatives holds every item that satisfied the query, from most to least preferred.
+	// The first alternative is always Item.
+	Alternatives []Alternative
+
+	// Fallback is true if nothing satisfied the query, and Item was provided by the Policy of the middleware instead.
+	// In that case Match, Q and Alternatives are all empty, as is Item for PassThrough.
+	Fallback bool
+}
+
+// Wildcard reports whether Item was chosen through a wildcard, such as "*/*" or "text/*",
+// rather than because the client asked for it by name.
+//
+// It is always false if the Value of Match doesn't implement Wildcarder.
+func (r Result) Wildcard() bool {
+	w, ok := r.Match.Value.(Wildcarder)
+	return ok && w.Wildcard()
+}
+
+// newResult returns a Result for the given alternatives, which must not be empty.
+func newResult(header string, alternatives []Alternative) Result {
+	best := alternatives[0]
+
+	return Result{
+		Header:       header,
+		Item:         best.Item,
+		Value:        best.Value,
+		Match:        best.Match,
+		Q:            best.Q,
+		Alternatives: alternatives,
+	}
+}
+
+type ctxKey string
+
+// Negotiated returns the Result of negotiating on a header processed by Middleware(),
+// and reports whether one was found.
+func Negotiated(r *http.Request, header string) (Result, bool) {
+	result, ok := r.Context().Value(ctxKey(http.CanonicalHeaderKey(header))).(Result)
+	return result, ok
+}
+
+// Item returns the item negotiated for in a header processed by Middleware().
+func Item(r *http.Request, header string) string {
+	result, _ := Negotiated(r, header)
+	return result.Item
+}
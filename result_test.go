@@ -0,0 +1,59 @@
+package negotiate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleNegotiated() {
+	middleware := ContentTypeMiddleware("application/json", "text/html")
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, _ := Negotiated(r, "Accept")
+		fmt.Printf("item=%s match=%q q=%g wildcard=%t alternatives=%d\n",
+			result.Item, result.Match, result.Q, result.Wildcard(), len(result.Alternatives))
+	}))
+
+	for _, accept := range []string{"application/json;q=0.9;indent=2, */*;q=0.1", "text/*"} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", accept)
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	// Output:
+	// item=application/json match="application/json; q=0.9; indent=2" q=0.9 wildcard=false alternatives=2
+	// item=text/html match="text/*" q=1 wildcard=true alternatives=1
+}
+
+func TestResult_Wildcard(t *testing.T) {
+	tests := []struct {
+		parser ValueParser
+		value  string
+		want   bool
+	}{
+		{ParseMedia, "*/*", true},
+		{ParseMedia, "text/*", true},
+		{ParseMedia, "text/html", false},
+		{ParseMedia, `text/html; a="*"`, false},
+		{ParseSimple, "*", true},
+		{ParseSimple, "gzip", false},
+		{ParseLocale, "*", true},
+		{ParseLocale, "en", false},
+		{ExtendedFiltering.Parser(), "de-*-DE", true},
+		{Lookup.Parser(), "*", true},
+		{Lookup.Parser(), "de", false},
+	}
+	for _, tt := range tests {
+		r := Result{Match: QValue{Value: Must(tt.parser(tt.value)), Q: 1}}
+
+		if got := r.Wildcard(); got != tt.want {
+			t.Errorf("Wildcard() for %s = %t, want %t", tt.value, got, tt.want)
+		}
+	}
+
+	if (Result{}).Wildcard() {
+		t.Error("Wildcard() for an empty Result = true")
+	}
+}
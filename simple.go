@@ -13,6 +13,10 @@ func (v simpleValue) String() string {
 	return string(v)
 }
 
+func (v simpleValue) Wildcard() bool {
+	return v == "*"
+}
+
 func (v simpleValue) Specificity() int {
 	if v == "*" {
 		return 0
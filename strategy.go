@@ -0,0 +1,71 @@
+package negotiate
+
+// Candidate is a choice that satisfies a query, as considered by a Strategy.
+type Candidate struct {
+	// Choice is the index of the choice, and Value is the choice itself.
+	Choice int
+	Value  Value
+
+	// Entry is the index of the query value the choice satisfied, and Match is that query value.
+	Entry int
+	Match QValue
+
+	// Q is the overall quality of the choice: the quality of Match multiplied by
+	// the source quality of Value, and by its score if it implements Scorer. It is always positive.
+	Q float64
+}
+
+// Strategy decides which of two acceptable candidates should be preferred.
+//
+// It returns true if a should be preferred over b, and must describe a strict weak ordering,
+// in the same way as the less function given to sort.Slice.
+type Strategy func(a, b Candidate) bool
+
+// ClientPreference is the default Strategy.
+//
+// It prefers the candidate with the highest overall quality. In the case of a tie,
+// the one that satisfied the query value with the higher precedence is used,
+// and then the one that appears first in the choices list.
+func ClientPreference(a, b Candidate) bool {
+	if a.Q != b.Q {
+		return a.Q > b.Q
+	}
+
+	if a.Entry != b.Entry {
+		return a.Entry < b.Entry
+	}
+
+	return a.Choice < b.Choice
+}
+
+// ServerPreference is a Strategy that prefers whichever acceptable candidate appears first
+// in the choices list, regardless of quality.
+func ServerPreference(a, b Candidate) bool {
+	return a.Choice < b.Choice
+}
+
+// ClientOrder is a Strategy that prefers the candidate satisfying the query value the client listed first,
+// regardless of quality, in the way SSH and TLS negotiate algorithms.
+//
+// Candidates satisfying the same query value are ordered by their position in the choices list.
+func ClientOrder(a, b Candidate) bool {
+	if a.Match.Index != b.Match.Index {
+		return a.Match.Index < b.Match.Index
+	}
+
+	return a.Choice < b.Choice
+}
+
+// PreferSmallest returns a Strategy that prefers the acceptable candidate with the smallest size,
+// as reported by size for the index of each choice.
+//
+// Candidates of the same size are ordered using ClientPreference.
+func PreferSmallest(size func(choice int) int64) Strategy {
+	return func(a, b Candidate) bool {
+		if sa, sb := size(a.Choice), size(b.Choice); sa != sb {
+			return sa < sb
+		}
+
+		return ClientPreference(a, b)
+	}
+}
@@ -0,0 +1,42 @@
+package negotiate
+
+import (
+	"fmt"
+)
+
+func ExampleStrategy() {
+	items := []string{"gzip", "br", "identity"}
+	sizes := []int64{1200, 1000, 4000}
+
+	strategies := []struct {
+		name     string
+		strategy Strategy
+	}{
+		{"ClientPreference", ClientPreference},
+		{"ServerPreference", ServerPreference},
+		{"ClientOrder", ClientOrder},
+		{"PreferSmallest", PreferSmallest(func(i int) int64 { return sizes[i] })},
+	}
+
+	for _, s := range strategies {
+		negotiate := Make(ParseSimple, items...).WithStrategy(s.strategy)
+		item, _ := negotiate.Process("identity;q=0.5, br;q=0.8, gzip")
+		fmt.Printf("%-16s -> %s\n", s.name, item)
+	}
+
+	// Output:
+	// ClientPreference -> gzip
+	// ServerPreference -> gzip
+	// ClientOrder      -> identity
+	// PreferSmallest   -> br
+}
+
+func ExampleServerPreference() {
+	negotiate := Make(ParseSimple, "br", "gzip").WithStrategy(ServerPreference)
+
+	item, _ := negotiate.Process("gzip, br;q=0.1")
+	fmt.Println(item)
+
+	// Output:
+	// br
+}
@@ -0,0 +1,218 @@
+package negotiate
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NegotiateDirectives are the directives of an RFC 2295 Negotiate request header,
+// by which a user agent says how it takes part in transparent content negotiation.
+type NegotiateDirectives struct {
+	// Trans is true if the user agent supports transparent content negotiation.
+	Trans bool
+
+	// VList is true if the user agent wants the variant list of a transparently negotiated resource,
+	// even when the server chooses a variant for it. It implies Trans.
+	VList bool
+
+	// GuessSmall is true if the user agent allows the server to guess at a variant,
+	// as long as the choice response is small.
+	GuessSmall bool
+
+	// RVSA holds the versions of the remote variant selection algorithm that the user agent allows
+	// the server to choose a variant with, such as "1.0".
+	//
+	// This package doesn't implement RFC 2296, so they don't allow it to choose.
+	RVSA []string
+
+	// Any is true if the server may choose a variant using any algorithm, as given by "*".
+	Any bool
+}
+
+// AllowsChoice reports whether the directives allow the server to choose a variant on behalf of the user agent,
+// using an algorithm that this package implements.
+//
+// The overall quality used by VariantSet.Choose isn't the full remote variant selection algorithm of RFC 2296,
+// so only "*" and "guess-small" allow a choice; an RVSA version such as "1.0" does not.
+func (d NegotiateDirectives) AllowsChoice() bool {
+	return d.Any || d.GuessSmall
+}
+
+// ParseNegotiate parses the combined value of the Negotiate header of r.
+//
+// Unknown directives are ignored, as required by RFC 2295.
+func ParseNegotiate(r *http.Request) NegotiateDirectives {
+	var d NegotiateDirectives
+
+	for _, directive := range strings.Split(FieldValue(r.Header, "Negotiate"), ",") {
+		switch directive = strings.ToLower(strings.Trim(directive, " \t")); directive {
+		case "trans":
+			d.Trans = true
+		case "vlist":
+			d.Trans, d.VList = true, true
+		case "guess-small":
+			d.GuessSmall = true
+		case "*":
+			d.Any = true
+		default:
+			// An rvsa-version is a number such as "1.0".
+			if _, err := strconv.ParseFloat(directive, 64); err == nil {
+				d.RVSA = append(d.RVSA, directive)
+			}
+		}
+	}
+
+	return d
+}
+
+// tcnAttribute returns the name of the RFC 2295 variant attribute for the dimension with the given header,
+// or an empty string if there isn't one.
+func tcnAttribute(header string) string {
+	switch http.CanonicalHeaderKey(header) {
+	case "Accept":
+		return "type"
+	case "Accept-Charset":
+		return "charset"
+	case "Accept-Language":
+		return "language"
+	}
+
+	return ""
+}
+
+// formatQuality formats q as a qvalue, with no more than three digits after the decimal point.
+func formatQuality(q float64) string {
+	return strconv.FormatFloat(math.Round(clampQuality(q)*1000)/1000, 'f', -1, 64)
+}
+
+// Alternates returns the value of an RFC 2295 Alternates header describing every variant in the set.
+//
+// The Name of each variant is used as its URI, and its items are described by
+// the type, charset and language attributes, according to the header of their dimension.
+func (s VariantSet) Alternates() string {
+	var b strings.Builder
+
+	for i, v := range s.variants {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(`{"`)
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v.Name))
+		b.WriteString(`" `)
+		b.WriteString(formatQuality(v.QS))
+
+		for j, item := range v.Items {
+			if attribute := tcnAttribute(s.dimensions[j].Header); item != "" && attribute != "" {
+				b.WriteString(" {" + attribute + " " + item + "}")
+			}
+		}
+
+		if len(v.Features) != 0 {
+			b.WriteString(" {features " + strings.Join(v.Features, " ") + "}")
+		}
+
+		b.WriteString("}")
+	}
+
+	return b.String()
+}
+
+// choices returns the variants as a list of Choice, for a list response.
+func (s VariantSet) choices() []Choice {
+	choices := make([]Choice, len(s.variants))
+
+	for i, v := range s.variants {
+		choices[i] = Choice{Href: v.Name, Item: v.Name}
+
+		for j, item := range v.Items {
+			switch tcnAttribute(s.dimensions[j].Header) {
+			case "type":
+				choices[i].Type = item
+			case "language":
+				choices[i].Language = item
+			}
+		}
+	}
+
+	return choices
+}
+
+type transparentHandler struct {
+	variantHandler
+}
+
+func (h transparentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	addVary(w.Header(), append([]string{"Negotiate"}, h.set.Headers()...)...)
+
+	directives := ParseNegotiate(r)
+	variant, chosen, e, ok := h.choose(r)
+
+	if directives.Trans {
+		w.Header().Set("Alternates", h.set.Alternates())
+	}
+
+	// A user agent that supports transparent negotiation is sent the variant list,
+	// unless it allows the server to choose for it and there is something to choose.
+	// Malformed requests are errors regardless.
+	var notAcceptable *NotAcceptableError
+
+	if directives.Trans && (errors.As(e.Err, &notAcceptable) || e.Err == nil && !directives.AllowsChoice()) {
+		w.Header().Set("TCN", "list")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusMultipleChoices)
+		choicesTemplate.Execute(w, h.set.choices())
+		return
+	}
+
+	if !ok {
+		h.fail(w, r, e)
+		return
+	}
+
+	// Responses to other user agents are server-driven, and aren't part of transparent negotiation.
+	if directives.Trans {
+		w.Header().Set("TCN", "choice")
+	}
+
+	if chosen {
+		w.Header().Set("Content-Location", variant.Name)
+	}
+
+	h.serve(w, r, variant, chosen, e)
+}
+
+// TransparentMiddleware returns http middleware that makes a resource transparently negotiable, as described by RFC 2295,
+// choosing between its variants using the same overall quality as VariantMiddleware.
+//
+// Negotiate along with the headers of every dimension is added to the Vary header.
+//
+// If the user agent supports transparent negotiation, as indicated by the "trans" or "vlist" directives
+// of its Negotiate header, the response carries an Alternates header describing the variants.
+// If it either doesn't allow the server to choose for it, or no variant is acceptable,
+// a list response is sent: a 300: Multiple Choices with a TCN header of "list" and an HTML list of the variants.
+// Otherwise a choice response is made, with a TCN header of "choice".
+//
+// User agents that don't support transparent negotiation get an ordinary server-driven response,
+// with no TCN or Alternates header.
+//
+// In both cases, when a variant is chosen, the Content-Location header is set to its Name and the next handler
+// is invoked to produce the variant, which it can retrieve using ChosenVariant(r).
+//
+// Failures are reported in the same way as VariantMiddleware, and the options are the same.
+// A request whose headers fail to parse or exceed the limits of the set gets an error even if the user agent
+// supports transparent negotiation, since a list response is only for when no variant is acceptable.
+// The Policy only applies to user agents that don't support transparent negotiation,
+// since those that do are sent a list response when no variant is acceptable.
+func TransparentMiddleware(set VariantSet, options ...Option) func(http.Handler) http.Handler {
+	h := transparentHandler{newVariantHandler(set, options)}
+
+	return func(next http.Handler) http.Handler {
+		h := h
+		h.next = next
+		return h
+	}
+}
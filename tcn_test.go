@@ -0,0 +1,103 @@
+package negotiate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleTransparentMiddleware() {
+	set := MakeVariants(
+		[]Dimension{ContentTypeDimension, LanguageDimension},
+		Variant{Name: "paper.1", QS: 0.9, Items: []string{"text/html", "en"}, Features: []string{"tables"}},
+		Variant{Name: "paper.2", QS: 0.7, Items: []string{"text/html", "fr"}},
+		Variant{Name: "paper.3", QS: 1, Items: []string{"application/postscript", "en"}},
+	)
+
+	handler := TransparentMiddleware(set)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		variant, _ := ChosenVariant(r)
+		fmt.Fprintf(w, "Sending %s\n", variant.Name)
+	}))
+
+	for _, negotiate := range []string{"", "trans", "trans, 1.0", "trans, *"} {
+		r := httptest.NewRequest("GET", "/paper", nil)
+		r.Header.Set("Accept", "text/html, application/postscript;q=0.5")
+		r.Header.Set("Accept-Language", "fr, en;q=0.5")
+		r.Header.Set("Negotiate", negotiate)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		fmt.Printf("Negotiate=%q: %d TCN=%q Content-Location=%q Alternates=%t\n",
+			negotiate, w.Code, w.Header().Get("TCN"), w.Header().Get("Content-Location"), w.Header().Get("Alternates") != "")
+	}
+
+	fmt.Println("Alternates:", set.Alternates())
+
+	// Output:
+	// Negotiate="": 200 TCN="" Content-Location="paper.2" Alternates=false
+	// Negotiate="trans": 300 TCN="list" Content-Location="" Alternates=true
+	// Negotiate="trans, 1.0": 300 TCN="list" Content-Location="" Alternates=true
+	// Negotiate="trans, *": 200 TCN="choice" Content-Location="paper.2" Alternates=true
+	// Alternates: {"paper.1" 0.9 {type text/html} {language en} {features tables}}, {"paper.2" 0.7 {type text/html} {language fr}}, {"paper.3" 1 {type application/postscript} {language en}}
+}
+
+func TestParseNegotiate(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Add("Negotiate", "Trans, vlist")
+	r.Header.Add("Negotiate", "guess-small, 1.0, 2.0, *, unknown")
+
+	want := NegotiateDirectives{Trans: true, VList: true, GuessSmall: true, RVSA: []string{"1.0", "2.0"}, Any: true}
+
+	if got := ParseNegotiate(r); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNegotiate() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNegotiateDirectives_AllowsChoice(t *testing.T) {
+	for negotiate, want := range map[string]bool{
+		"":                   false,
+		"trans":              false,
+		"trans, 1.0":         false,
+		"trans, 2.0":         false,
+		"trans, guess-small": true,
+		"trans, *":           true,
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Negotiate", negotiate)
+
+		if got := ParseNegotiate(r).AllowsChoice(); got != want {
+			t.Errorf("Negotiate: %s AllowsChoice() = %t, want %t", negotiate, got, want)
+		}
+	}
+}
+
+func TestTransparentMiddleware_errors(t *testing.T) {
+	set := MakeVariants([]Dimension{ContentTypeDimension}, NewVariant("a.html", "text/html")).WithLimits(Limits{MaxBytes: 32})
+	handler := TransparentMiddleware(set)(http.NotFoundHandler())
+
+	tests := []struct {
+		accept string
+		want   int
+	}{
+		{"image/png", http.StatusMultipleChoices},
+		{"text/html;q=x", http.StatusBadRequest},
+		{strings.Repeat("text/html, ", 10), http.StatusRequestHeaderFieldsTooLarge},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", tt.accept)
+		r.Header.Set("Negotiate", "trans, *")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tt.want {
+			t.Errorf("Accept: %s gave status %d, want %d", tt.accept, w.Code, tt.want)
+		}
+	}
+}
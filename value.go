@@ -30,3 +30,43 @@ func Must(value Value, err error) Value {
 
 	return value
 }
+
+// Wildcarder is an optional interface for values that can be wildcards, such as "*/*" or "text/*".
+//
+// All of the values returned by the parsers in this package implement it.
+type Wildcarder interface {
+	Value
+
+	// Wildcard reports whether the value is a wildcard, or has a wildcard in place of any part of it.
+	Wildcard() bool
+}
+
+// Scorer is an optional interface for values that can report how closely they satisfy another value,
+// rather than only whether they do, such as a regional variant of a language.
+//
+// When a query is matched against a Scorer, the quality given by the query is multiplied by the score.
+// Partial scores are only used when the Scorer doesn't fully satisfy any value in the query,
+// so an explicit weight for a value always takes precedence over a close match to another.
+type Scorer interface {
+	Value
+
+	// Score returns how closely this value satisfies the passed value, in the range 0 through 1.
+	// A score of 1 means it is fully satisfied, and a score of 0 means it isn't satisfied at all.
+	//
+	// Should panic if value is a different type of value.
+	Score(Value) float64
+}
+
+// score returns how closely v satisfies ref.
+// Values that don't implement Scorer score 1 if they satisfy ref, and 0 otherwise.
+func score(v, ref Value) float64 {
+	if s, ok := v.(Scorer); ok {
+		return clampQuality(s.Score(ref))
+	}
+
+	if v.Satisfies(ref) {
+		return 1
+	}
+
+	return 0
+}
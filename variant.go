@@ -0,0 +1,270 @@
+package negotiate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Dimension is a header that the variants of a resource can differ along,
+// along with the parser for its values.
+type Dimension struct {
+	Header string
+	Parser ValueParser
+}
+
+// The dimensions negotiated on by the middlewares in this package.
+var (
+	ContentTypeDimension = Dimension{"Accept", ParseMedia}
+	LanguageDimension    = Dimension{"Accept-Language", ParseLocale}
+	CharsetDimension     = Dimension{"Accept-Charset", ParseSimple}
+	EncodingDimension    = Dimension{"Accept-Encoding", ParseSimple}
+)
+
+// Variant is one concrete representation of a resource.
+type Variant struct {
+	// Name identifies the variant, such as a file name or URL.
+	Name string
+
+	// QS is the source quality of the variant, in the range 0 through 1.
+	// A variant with a source quality of 0 is never chosen, so use NewVariant
+	// for a variant without a preference.
+	QS float64
+
+	// Items holds the item of the variant for each dimension of its VariantSet, in the same order.
+	//
+	// An empty or missing item means the variant doesn't vary along that dimension,
+	// so that any query for it is satisfied with a quality of 1.
+	Items []string
+
+	// Features lists the RFC 2295 feature tags of the variant, such as "tables" or "!frames".
+	// They are only used to describe the variant in an Alternates header.
+	Features []string
+}
+
+// NewVariant returns a Variant with the given name and items, and a source quality of 1.
+func NewVariant(name string, items ...string) Variant {
+	return Variant{Name: name, QS: 1, Items: items}
+}
+
+// VariantSet holds the variants of a resource, and chooses between them
+// by considering all of their dimensions at once.
+//
+// Negotiating on each dimension separately can produce a combination that doesn't exist,
+// such as French CSV when only French PDF and English CSV are available.
+// Instead, each variant is given an overall quality, which is the product of its source quality
+// and the quality of each of its items, in the manner of the remote variant selection algorithm
+// of RFC 2296, and the variant with the highest overall quality is chosen.
+type VariantSet struct {
+	dimensions []Dimension
+	variants   []Variant
+	values     [][]Value
+	limits     Limits
+}
+
+// MakeVariants returns a VariantSet for the given dimensions and variants.
+//
+// The variants should be ordered with the more compatible ones first, so that they
+// will be preferred in the event of a tie.
+//
+// This function will panic if any of the items fail to parse,
+// or if a variant has more items than there are dimensions.
+func MakeVariants(dimensions []Dimension, variants ...Variant) VariantSet {
+	values := make([][]Value, len(variants))
+
+	for i, variant := range variants {
+		if len(variant.Items) > len(dimensions) {
+			panic(fmt.Errorf("variant %q has %d items, but there are only %d dimensions", variant.Name, len(variant.Items), len(dimensions)))
+		}
+
+		values[i] = make([]Value, len(dimensions))
+
+		for j, item := range variant.Items {
+			if item != "" {
+				values[i][j] = Must(dimensions[j].Parser(item))
+			}
+		}
+	}
+
+	return VariantSet{dimensions: dimensions, variants: variants, values: values}
+}
+
+// WithLimits returns a copy of s that parses queries subject to the given limits,
+// instead of DefaultLimits.
+func (s VariantSet) WithLimits(limits Limits) VariantSet {
+	s.limits = limits
+	return s
+}
+
+// Headers returns the names of the headers of each dimension.
+func (s VariantSet) Headers() []string {
+	headers := make([]string, len(s.dimensions))
+
+	for i, d := range s.dimensions {
+		headers[i] = http.CanonicalHeaderKey(d.Header)
+	}
+
+	return headers
+}
+
+// names returns the names of all of the variants.
+func (s VariantSet) names() []string {
+	names := make([]string, len(s.variants))
+
+	for i, v := range s.variants {
+		names[i] = v.Name
+	}
+
+	return names
+}
+
+// String returns the names of all of the variants as a comma seperated list.
+func (s VariantSet) String() string {
+	return strings.Join(s.names(), ", ")
+}
+
+// Choose returns the variant with the highest overall quality for the request, along with that quality,
+// prefering the earlier variants given to MakeVariants in the event of a tie.
+//
+// Returns a *NotAcceptableError if no variant has a positive overall quality.
+// Its Items are the names of the variants, and its Header and Query are empty.
+//
+// Returns a *ParseError if a header is malformed or the value parser returns an error.
+func (s VariantSet) Choose(r *http.Request) (variant Variant, q float64, err error) {
+	queries := make([]Query, len(s.dimensions))
+
+	for i, d := range s.dimensions {
+		header := http.CanonicalHeaderKey(d.Header)
+
+		if queries[i], err = ParseQueryLimits(d.Parser, FieldValue(r.Header, header), s.limits); err != nil {
+			return Variant{}, 0, withHeader(err, header)
+		}
+	}
+
+	best := -1
+
+	for i, v := range s.variants {
+		quality := clampQuality(v.QS)
+
+		for j, value := range s.values[i] {
+			if value == nil || quality <= 0 {
+				continue
+			}
+
+			if k, score := queries[j].find(value); k != -1 {
+				quality *= queries[j][k].Q * score
+			} else {
+				quality = 0
+			}
+		}
+
+		if quality > q {
+			best, q = i, quality
+		}
+	}
+
+	if best == -1 {
+		return Variant{}, 0, &NotAcceptableError{Items: s.names()}
+	}
+
+	return s.variants[best], q, nil
+}
+
+type variantHandler struct {
+	set  VariantSet
+	next http.Handler
+	config
+
+	fallback                 Variant
+	hasFallback, canFallback bool
+}
+
+type variantKey struct{}
+
+// ChosenVariant returns the variant chosen by VariantMiddleware, and reports whether there was one.
+func ChosenVariant(r *http.Request) (Variant, bool) {
+	variant, ok := r.Context().Value(variantKey{}).(Variant)
+	return variant, ok
+}
+
+// choose chooses a variant for r, falling back on the Policy if none is acceptable,
+// and reports whether the next handler should be invoked.
+// The Event describes the outcome, with the name of the chosen variant, if any, as its Result.Item.
+func (h variantHandler) choose(r *http.Request) (variant Variant, chosen bool, e Event, ok bool) {
+	variant, q, err := h.set.Choose(r)
+	e = Event{Items: h.set.names(), Err: err}
+
+	var parseError *ParseError
+
+	switch {
+	case err == nil:
+		e.Result = Result{Item: variant.Name, Q: q}
+		return variant, true, e, true
+	case errors.Is(err, ErrNotAcceptable) && h.canFallback:
+		e.Result = Result{Item: h.fallback.Name, Fallback: true}
+		return h.fallback, h.hasFallback, e, true
+	case errors.As(err, &parseError):
+		e.Header = parseError.Header
+	}
+
+	return Variant{}, false, e, false
+}
+
+// serve invokes the next handler with the variant, if one was chosen.
+func (h variantHandler) serve(w http.ResponseWriter, r *http.Request, variant Variant, chosen bool, e Event) {
+	if h.onNegotiated != nil {
+		h.onNegotiated(w, r, e)
+	}
+
+	if chosen {
+		r = r.WithContext(context.WithValue(r.Context(), variantKey{}, variant))
+	}
+
+	h.next.ServeHTTP(w, r)
+}
+
+func (h variantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	addVary(w.Header(), h.set.Headers()...)
+
+	if variant, chosen, e, ok := h.choose(r); ok {
+		h.serve(w, r, variant, chosen, e)
+	} else {
+		h.fail(w, r, e)
+	}
+}
+
+// newVariantHandler returns a variantHandler for set, configured by options.
+func newVariantHandler(set VariantSet, options []Option) variantHandler {
+	h := variantHandler{set: set, config: newConfig(options)}
+	h.fallback, h.hasFallback, h.canFallback = h.policy.resolveVariant(set)
+	return h
+}
+
+// VariantMiddleware returns http middleware that chooses between the variants of a resource.
+//
+// The headers of every dimension are added to the Vary header of the response.
+//
+// If a variant is chosen, the next handler will be invoked, and can retrieve the variant using ChosenVariant(r).
+//
+// A 406: Not Acceptable error will be generated if no variant is acceptable.
+//
+// A 400: Bad Request error will be generated if any of the headers fail to parse.
+//
+// A 431: Request Header Fields Too Large error will be generated if a header exceeds the limits of the set.
+//
+// The options change the behaviour of the middleware as for MiddlewareFor, except that WithVariants has no effect.
+// The Policy applies when no variant is acceptable, and the Events passed to the hooks describe the variants:
+// their Items are the names of the variants, and their Header is empty unless a header failed to parse.
+//
+// This function will panic if the Policy names a variant that isn't in the set.
+func VariantMiddleware(set VariantSet, options ...Option) func(http.Handler) http.Handler {
+	h := newVariantHandler(set, options)
+
+	return func(next http.Handler) http.Handler {
+		h := h
+		h.next = next
+		return h
+	}
+}
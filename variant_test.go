@@ -0,0 +1,116 @@
+package negotiate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleVariantSet() {
+	set := MakeVariants(
+		[]Dimension{ContentTypeDimension, LanguageDimension, CharsetDimension},
+		Variant{Name: "report.en.html", QS: 1, Items: []string{"text/html", "en"}},
+		Variant{Name: "report.fr.pdf", QS: 0.9, Items: []string{"application/pdf", "fr"}},
+		Variant{Name: "report.en.csv", QS: 0.8, Items: []string{"text/csv", "en", "iso-8859-1"}},
+	)
+
+	requests := []struct {
+		accept, language, charset string
+	}{
+		{"", "", ""},
+		{"text/csv, application/pdf;q=0.5", "fr, en;q=0.5", ""},
+		{"text/csv, application/pdf;q=0.5", "fr, en;q=0.5", "utf-8"},
+		{"text/csv", "de", ""},
+	}
+
+	for _, req := range requests {
+		r := httptest.NewRequest("GET", "/report", nil)
+		r.Header.Set("Accept", req.accept)
+		r.Header.Set("Accept-Language", req.language)
+		r.Header.Set("Accept-Charset", req.charset)
+
+		if variant, q, err := set.Choose(r); err != nil {
+			fmt.Printf("error: %v\n", err)
+		} else {
+			fmt.Printf("%s q=%.3g\n", variant.Name, q)
+		}
+	}
+
+	// Output:
+	// report.en.html q=1
+	// report.fr.pdf q=0.45
+	// report.fr.pdf q=0.45
+	// error: no item satisfies query
+}
+
+func TestNewVariant(t *testing.T) {
+	set := MakeVariants([]Dimension{ContentTypeDimension},
+		NewVariant("a.html", "text/html"),
+		Variant{Name: "a.txt", Items: []string{"text/plain"}})
+
+	r := httptest.NewRequest("GET", "/", nil)
+
+	if variant, q, err := set.Choose(r); err != nil || variant.Name != "a.html" || q != 1 {
+		t.Errorf("Choose() = %v, %v, %v", variant, q, err)
+	}
+
+	r.Header.Set("Accept", "text/plain")
+
+	if variant, _, err := set.Choose(r); err == nil {
+		t.Errorf("Choose() = %v, want error", variant)
+	}
+
+	if got, want := set.Alternates(), `{"a.html" 1 {type text/html}}, {"a.txt" 0 {type text/plain}}`; got != want {
+		t.Errorf("Alternates() = %s, want %s", got, want)
+	}
+}
+
+func TestVariantMiddleware_options(t *testing.T) {
+	set := MakeVariants([]Dimension{ContentTypeDimension},
+		NewVariant("a.html", "text/html"),
+		NewVariant("a.txt", "text/plain"))
+
+	tests := []struct {
+		policy Policy
+		accept string
+		want   string
+	}{
+		{Strict, "text/plain", "a.txt"},
+		{Strict, "image/png", "406 [a.html a.txt]"},
+		{Strict, "text/html;q=x", "400 Accept"},
+		{FallbackFirst, "image/png", "a.html"},
+		{Fallback("a.txt"), "image/png", "a.txt"},
+		{PassThrough, "image/png", "none"},
+	}
+
+	for _, tt := range tests {
+		var got string
+
+		hook := func(w http.ResponseWriter, r *http.Request, e Event) {
+			if errors.Is(e.Err, ErrNotAcceptable) {
+				got = fmt.Sprint(http.StatusNotAcceptable, " ", e.Items)
+			} else {
+				got = fmt.Sprint(http.StatusBadRequest, " ", e.Header)
+			}
+		}
+
+		handler := VariantMiddleware(set, WithPolicy(tt.policy), OnNotAcceptable(hook), OnBadRequest(hook))(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if variant, ok := ChosenVariant(r); ok {
+					got = variant.Name
+				} else {
+					got = "none"
+				}
+			}))
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", tt.accept)
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+
+		if got != tt.want {
+			t.Errorf("%v with Accept: %s gave %q, want %q", tt.policy, tt.accept, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package negotiate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// This file implements the Variants and Variant-Key response headers of
+// draft-ietf-httpbis-variants-06, which let a cache select a stored response
+// by running the negotiation itself, rather than keying on the raw request headers listed in Vary.
+
+// sfItem formats s as a structured field bare item: a token if possible, or a string otherwise.
+func sfItem(s string) string {
+	token := s != "" && (s[0] == '*' || ('a' <= s[0] && s[0] <= 'z') || ('A' <= s[0] && s[0] <= 'Z'))
+
+	for i := 1; token && i < len(s); i++ {
+		token = isTchar(s[i]) || s[i] == ':' || s[i] == '/'
+	}
+
+	if token {
+		return s
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// sfInnerList formats items as a structured field inner list.
+func sfInnerList(items []string) string {
+	formatted := make([]string, len(items))
+
+	for i, item := range items {
+		formatted[i] = sfItem(item)
+	}
+
+	return "(" + strings.Join(formatted, " ") + ")"
+}
+
+// noVariantsKey marks a request whose response can't be described by the Variants header.
+type noVariantsKey struct{}
+
+// variantsMember reports whether the Variants header of h already has a member for header.
+func variantsMember(h http.Header, header string) bool {
+	name := strings.ToLower(header)
+
+	for _, member := range strings.Split(h.Get("Variants"), ",") {
+		if strings.HasPrefix(strings.TrimSpace(member), name+"=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addVariants adds a member describing the available items of each event to the Variants header of h,
+// and its chosen item to the end of the Variant-Key header, so that stacked middlewares build up a single key.
+//
+// A cache can only reproduce a result that it would choose itself from the available items,
+// which excludes a fallback other than the first item, and the combined result of two middlewares
+// negotiating on the same header. For these, both headers are removed, leaving caches to use Vary instead,
+// and the returned context stops inner middlewares from adding them again.
+func addVariants(ctx context.Context, h http.Header, events []Event) context.Context {
+	if ctx.Value(noVariantsKey{}) != nil {
+		return ctx
+	}
+
+	for _, e := range events {
+		if variantsMember(h, e.Header) || e.Result.Fallback && (len(e.Items) == 0 || e.Result.Item != e.Items[0]) {
+			h.Del("Variants")
+			h.Del("Variant-Key")
+
+			return context.WithValue(ctx, noVariantsKey{}, true)
+		}
+	}
+
+	for _, e := range events {
+		member := strings.ToLower(e.Header) + "=" + sfInnerList(e.Items)
+
+		if variants := h.Get("Variants"); variants != "" {
+			member = variants + ", " + member
+		}
+
+		h.Set("Variants", member)
+
+		key := sfInnerList([]string{e.Result.Item})
+
+		if existing := h.Get("Variant-Key"); strings.HasSuffix(existing, ")") {
+			key = strings.TrimSuffix(existing, ")") + " " + sfItem(e.Result.Item) + ")"
+		}
+
+		h.Set("Variant-Key", key)
+	}
+
+	return ctx
+}
+
+// WithVariants is an Option that adds the Variants and Variant-Key headers of draft-ietf-httpbis-variants
+// to responses where an item was chosen, listing every offered item and the chosen one respectively.
+//
+// When several middlewares using this option are stacked, their headers are combined
+// into a single Variants dictionary and a single Variant-Key.
+//
+// The headers are left out when a cache couldn't reproduce the result using VariantKey:
+// when the Policy provides an item other than the first, or no item at all,
+// and when two of the middlewares negotiate on the same header.
+func WithVariants() Option {
+	return func(c *config) {
+		c.variants = true
+	}
+}
+
+// parseVariants parses the value of a Variants header into its header names and their available values.
+func parseVariants(variants string) (headers []string, available [][]string, err error) {
+	s := fieldScanner{s: variants}
+
+	for {
+		s.skipOWS()
+
+		if s.done() {
+			return headers, available, nil
+		}
+
+		name := s.token()
+
+		if name == "" || s.done() || s.peek() != '=' {
+			return nil, nil, s.errorf("expected member name")
+		}
+
+		s.pos++
+
+		if s.done() || s.peek() != '(' {
+			return nil, nil, s.errorf("expected inner list")
+		}
+
+		s.pos++
+
+		var values []string
+
+		for {
+			for !s.done() && s.peek() == ' ' {
+				s.pos++
+			}
+
+			if s.done() {
+				return nil, nil, s.errorf("unterminated inner list")
+			}
+
+			if s.peek() == ')' {
+				s.pos++
+				break
+			}
+
+			var value string
+
+			if s.peek() == '"' {
+				if value, err = s.quotedString(); err != nil {
+					return nil, nil, err
+				}
+			} else {
+				start := s.pos
+
+				for !s.done() && (isTchar(s.peek()) || s.peek() == ':' || s.peek() == '/') {
+					s.pos++
+				}
+
+				if value = s.s[start:s.pos]; value == "" {
+					return nil, nil, s.errorf("unexpected character %q", s.peek())
+				}
+			}
+
+			values = append(values, value)
+		}
+
+		headers = append(headers, http.CanonicalHeaderKey(name))
+		available = append(available, values)
+
+		s.skipOWS()
+
+		if !s.done() {
+			if s.peek() != ',' {
+				return nil, nil, s.errorf("expected ','")
+			}
+
+			s.pos++
+		}
+	}
+}
+
+// variantParser returns the ValueParser used by the middlewares in this package for header,
+// or ParseSimple for headers it doesn't know.
+func variantParser(header string) ValueParser {
+	switch http.CanonicalHeaderKey(header) {
+	case "Accept":
+		return ParseMedia
+	case "Accept-Language":
+		return ParseLocale
+	}
+
+	return ParseSimple
+}
+
+// VariantKey computes the Variant-Key that a request should be served from,
+// given the Variants header of a stored response, for use by caches.
+//
+// Each header listed by the Variants header is negotiated against its available values.
+// If one of axes is for the header, its Negotiate is used, so that the matching, strategy and source qualities
+// agree with the middleware that wrote the response; axes should be those given to that middleware.
+// Its items must be the available values, or else an error is returned, since the response was made by
+// a differently configured middleware.
+// Other headers are negotiated using the parser of the corresponding middleware in this package
+// (ParseSimple for any other header) and the default strategy.
+//
+// As described by the draft, if none of the available values are acceptable, the first is used.
+//
+// The result is formatted in the same way as the Variant-Key header written by WithVariants,
+// so a stored response can be used if the two are equal.
+func VariantKey(variants string, r *http.Request, axes ...Axis) (string, error) {
+	headers, available, err := parseVariants(variants)
+
+	if err != nil {
+		return "", fmt.Errorf("bad Variants header: %w", err)
+	}
+
+	key := make([]string, len(headers))
+
+	for i, header := range headers {
+		if len(available[i]) == 0 {
+			return "", fmt.Errorf("bad Variants header: no available values for %s", header)
+		}
+
+		negotiate, err := variantNegotiate(header, available[i], axes)
+
+		if err != nil {
+			return "", fmt.Errorf("bad Variants header: %w", err)
+		}
+
+		switch item, err := negotiate.ProcessRequest(r, header); {
+		case err == nil:
+			key[i] = item
+		case errors.Is(err, ErrNotAcceptable):
+			key[i] = available[i][0]
+		default:
+			return "", err
+		}
+	}
+
+	return sfInnerList(key), nil
+}
+
+// variantNegotiate returns the Negotiate for the available values of header, taken from axes if possible.
+func variantNegotiate(header string, available []string, axes []Axis) (Negotiate, error) {
+	for _, axis := range axes {
+		if http.CanonicalHeaderKey(axis.Header) != header {
+			continue
+		}
+
+		if !reflect.DeepEqual(axis.Negotiate.items, available) {
+			return Negotiate{}, fmt.Errorf("available values for %s are %s, but %s are offered", header, sfInnerList(available), sfInnerList(axis.Negotiate.items))
+		}
+
+		return axis.Negotiate, nil
+	}
+
+	parser := variantParser(header)
+
+	// The stored header is not trusted, so it is checked before Make would panic on it.
+	for _, value := range available {
+		if _, err := parser(value); err != nil {
+			return Negotiate{}, err
+		}
+	}
+
+	return Make(parser, available...), nil
+}
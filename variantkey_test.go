@@ -0,0 +1,139 @@
+package negotiate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleWithVariants() {
+	handler := MiddlewareFor("Accept-Encoding", Make(ParseSimple, "gzip", "br"), WithVariants())(
+		MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr", "de"), WithVariants())(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "br")
+	r.Header.Set("Accept-Language", "fr-CA, fr")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	fmt.Println("Variants:", w.Header().Get("Variants"))
+	fmt.Println("Variant-Key:", w.Header().Get("Variant-Key"))
+
+	// A cache can compute the key for a later request from the stored Variants header,
+	// without the two requests having the same Accept-Language.
+	later := httptest.NewRequest("GET", "/", nil)
+	later.Header.Set("Accept-Encoding", "gzip;q=0.5, br")
+	later.Header.Set("Accept-Language", "fr;q=0.9, ja")
+
+	key, _ := VariantKey(w.Header().Get("Variants"), later)
+	fmt.Println("VariantKey:", key)
+
+	// Output:
+	// Variants: accept-encoding=(gzip br), accept-language=(en fr de)
+	// Variant-Key: (br fr)
+	// VariantKey: (br fr)
+}
+
+func TestVariantKey(t *testing.T) {
+	tests := []struct {
+		variants string
+		accept   string
+		want     string
+		wantErr  bool
+	}{
+		{"accept=(text/html application/json)", "application/json", "(application/json)", false},
+		{"accept=(text/html application/json)", "image/png", "(text/html)", false},
+		{`accept-language=("en" fr)`, "", "(en)", false},
+		{"accept=(text/html", "", "", true},
+		{"accept=()", "", "", true},
+		{`accept=("i like waffles.")`, "", "", true},
+		{"accept=(text/html)", "i like waffles.", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.variants, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept", tt.accept)
+
+			got, err := VariantKey(tt.variants, r)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VariantKey(%q) error = %v, wantErr %v", tt.variants, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("VariantKey(%q) = %q, want %q", tt.variants, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariantKey_axes(t *testing.T) {
+	axis := Axis{"Accept-Language", MakeLanguage(Lookup, "en", "de")}
+	handler := MiddlewareFor(axis.Header, axis.Negotiate, WithVariants())(http.NotFoundHandler())
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Language", "de-AT")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	key, err := VariantKey(w.Header().Get("Variants"), r, axis)
+
+	if want := w.Header().Get("Variant-Key"); err != nil || key != want || want != "(de)" {
+		t.Errorf("VariantKey() = %q, %v, want %q", key, err, want)
+	}
+
+	other := Axis{"Accept-Language", MakeLanguage(Lookup, "en", "fr")}
+
+	if key, err := VariantKey(w.Header().Get("Variants"), r, other); err == nil {
+		t.Errorf("VariantKey() with different items = %q, want error", key)
+	}
+}
+
+func TestWithVariants_uncacheable(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.Handler
+		want    string
+	}{
+		{
+			"FallbackFirst",
+			MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr"), WithVariants(), WithPolicy(FallbackFirst))(http.NotFoundHandler()),
+			"(en)",
+		},
+		{
+			"Fallback",
+			MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr"), WithVariants(), WithPolicy(Fallback("de")))(http.NotFoundHandler()),
+			"",
+		},
+		{
+			"PassThrough",
+			MiddlewareFor("Accept-Encoding", Make(ParseSimple, "gzip"), WithVariants())(
+				MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr"), WithVariants(), WithPolicy(PassThrough))(http.NotFoundHandler())),
+			"",
+		},
+		{
+			"same header",
+			MiddlewareFor("Accept-Language", Make(ParseLocale, "en", "fr", "de"), WithVariants())(
+				MiddlewareFor("Accept-Language", Make(ParseLocale, "de"), WithVariants())(
+					MiddlewareFor("Accept-Encoding", Make(ParseSimple, "gzip"), WithVariants())(http.NotFoundHandler()))),
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept-Language", "ja, de")
+
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Variant-Key"); got != tt.want || (got == "") != (w.Header().Get("Variants") == "") {
+				t.Errorf("Variant-Key = %q with Variants = %q, want %q", got, w.Header().Get("Variants"), tt.want)
+			}
+		})
+	}
+}